@@ -0,0 +1,13 @@
+// Package cmd goblog 的命令行子命令
+package cmd
+
+import (
+	"goblog/bootstrap"
+
+	"github.com/spf13/cobra"
+)
+
+// setupDB 需要数据库的命令在 PreRun 中调用
+func setupDB(cmd *cobra.Command, args []string) {
+    bootstrap.SetUpDB()
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"goblog/pkg/console"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdKeyGenerate 生成 APP_KEY 并写入 .env 文件
+var CmdKeyGenerate = &cobra.Command{
+    Use:   "key:generate",
+    Short: "Generate a new APP_KEY and write it to the .env file",
+    Run:   runKeyGenerate,
+    Args:  cobra.NoArgs,
+}
+
+// envFile 密钥写入的环境变量文件，与 pkg/config 读取的文件一致
+var envFile = ".env"
+
+// publicKeys 曾随代码仓库公开的密钥，任何人都能读取，serve 拒绝使用
+var publicKeys = []string{
+    "33446a9dcf9ea060a0a6532b166da32f304af0de",
+}
+
+func init() {
+    CmdKeyGenerate.Flags().Bool("show", false, "Display the key instead of modifying the .env file")
+}
+
+func runKeyGenerate(cmd *cobra.Command, args []string) {
+    key, err := generateKey()
+    console.ExitIf(err)
+
+    if show, _ := cmd.Flags().GetBool("show"); show {
+        fmt.Println(key)
+        return
+    }
+
+    console.ExitIf(writeEnvKey(envFile, "APP_KEY", key))
+    console.Success("Application key set successfully.")
+    console.Warning("已有的会话和 Cookie 将全部失效，用户需要重新登录")
+}
+
+// isPublicKey 密钥是否为公开的示例密钥
+func isPublicKey(key string) bool {
+    for _, publicKey := range publicKeys {
+        if key == publicKey {
+            return true
+        }
+    }
+    return false
+}
+
+// generateKey 生成 32 字节的随机密钥，以十六进制输出
+func generateKey() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(b), nil
+}
+
+// writeEnvKey 更新 .env 文件中的某一项，不存在时追加到文件末尾
+func writeEnvKey(file string, name string, value string) error {
+    content, err := ioutil.ReadFile(file)
+    if err != nil && !os.IsNotExist(err) {
+        return err
+    }
+
+    line := name + "=" + value
+    pattern := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(name) + `=.*$`)
+    if pattern.Match(content) {
+        content = pattern.ReplaceAllLiteral(content, []byte(line))
+    } else {
+        if len(content) > 0 && content[len(content)-1] != '\n' {
+            content = append(content, '\n')
+        }
+        content = append(content, []byte(line+"\n")...)
+    }
+
+    return ioutil.WriteFile(file, content, 0644)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"goblog/pkg/config"
+	"goblog/pkg/console"
+	"goblog/pkg/migrate"
+
+	// 注册 database/migrations 下的所有迁移文件
+	_ "goblog/database/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdMigrate 执行所有未执行的迁移
+var CmdMigrate = &cobra.Command{
+    Use:    "migrate",
+    Short:  "Run all pending database migrations",
+    PreRun: setupDB,
+    Run:    runMigrateUp,
+    Args:   cobra.NoArgs,
+}
+
+// CmdMigrateRollback 回滚上一批次的迁移
+var CmdMigrateRollback = &cobra.Command{
+    Use:    "migrate:rollback",
+    Short:  "Rollback the last batch of database migrations",
+    PreRun: setupDB,
+    Run:    runMigrateRollback,
+    Args:   cobra.NoArgs,
+}
+
+// CmdMigrateStatus 显示每个迁移的执行状态
+var CmdMigrateStatus = &cobra.Command{
+    Use:    "migrate:status",
+    Short:  "Show the status of each migration",
+    PreRun: setupDB,
+    Run:    runMigrateStatus,
+    Args:   cobra.NoArgs,
+}
+
+// CmdMigrateFresh 删除所有表并重新执行所有迁移
+var CmdMigrateFresh = &cobra.Command{
+    Use:    "migrate:fresh",
+    Short:  "Drop all tables and re-run all migrations",
+    PreRun: setupDB,
+    Run:    runMigrateFresh,
+    Args:   cobra.NoArgs,
+}
+
+func init() {
+    CmdMigrateFresh.Flags().Bool("force", false, "Force the operation to run when in production")
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) {
+    migrator, err := migrate.NewMigrator()
+    console.ExitIf(err)
+    migrated, err := migrator.Up()
+    printMigrations("Migrated", migrated)
+    console.ExitIf(err)
+    if len(migrated) == 0 {
+        console.Success("Nothing to migrate.")
+    }
+}
+
+func runMigrateRollback(cmd *cobra.Command, args []string) {
+    migrator, err := migrate.NewMigrator()
+    console.ExitIf(err)
+    rolledBack, err := migrator.Rollback()
+    printMigrations("Rolled back", rolledBack)
+    console.ExitIf(err)
+    if len(rolledBack) == 0 {
+        console.Success("Nothing to rollback.")
+    }
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) {
+    migrator, err := migrate.NewMigrator()
+    console.ExitIf(err)
+    statuses, err := migrator.Status()
+    console.ExitIf(err)
+
+    fmt.Printf("%-6s %-6s %s\n", "Ran?", "Batch", "Migration")
+    for _, status := range statuses {
+        if status.Ran {
+            fmt.Printf("%-6s %-6d %s\n", "Yes", status.Batch, status.Migration)
+        } else {
+            fmt.Printf("%-6s %-6s %s\n", "No", "", status.Migration)
+        }
+    }
+}
+
+func runMigrateFresh(cmd *cobra.Command, args []string) {
+    // 生产环境删除全部数据前须显式确认
+    if force, _ := cmd.Flags().GetBool("force"); !force && config.GetString("app.env") == "production" {
+        console.Exit("Application is in production, use --force to drop all tables.")
+    }
+
+    console.Warning("Dropping all tables.")
+    migrator, err := migrate.NewMigrator()
+    console.ExitIf(err)
+    migrated, err := migrator.Fresh()
+    printMigrations("Migrated", migrated)
+    console.ExitIf(err)
+}
+
+// printMigrations 逐行打印执行过的迁移
+func printMigrations(action string, migrations []string) {
+    for _, name := range migrations {
+        console.Success(fmt.Sprintf("%s: %s", action, name))
+    }
+}
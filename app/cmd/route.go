@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"goblog/bootstrap"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+)
+
+// CmdRouteList 列出所有命名路由
+var CmdRouteList = &cobra.Command{
+    Use:   "route:list",
+    Short: "List all named routes",
+    Run:   runRouteList,
+    Args:  cobra.NoArgs,
+}
+
+func runRouteList(cmd *cobra.Command, args []string) {
+    router := bootstrap.SetupRoute()
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "METHOD\tURI\tNAME")
+
+    router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+        name := route.GetName()
+        if len(name) == 0 {
+            return nil
+        }
+        path, _ := route.GetPathTemplate()
+        methods, _ := route.GetMethods()
+        fmt.Fprintf(w, "%s\t%s\t%s\n", strings.Join(methods, "|"), path, name)
+        return nil
+    })
+
+    w.Flush()
+}
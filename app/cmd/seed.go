@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"goblog/database/seeders"
+	"goblog/pkg/console"
+	"goblog/pkg/model"
+	"goblog/pkg/seed"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdSeed 填充数据库
+var CmdSeed = &cobra.Command{
+    Use:    "seed [seeder]",
+    Short:  "Seed the database, runs all seeders when no name is given",
+    PreRun: setupDB,
+    Run:    runSeeders,
+    Args:   cobra.MaximumNArgs(1),
+}
+
+func runSeeders(cmd *cobra.Command, args []string) {
+    seeders.Initialize()
+
+    if len(args) > 0 {
+        console.ExitIf(seed.RunSeeder(model.DB, args[0]))
+        console.Success("Seeded: " + args[0])
+        return
+    }
+
+    names := seed.Names()
+    if len(names) == 0 {
+        console.Warning("Nothing to seed.")
+        return
+    }
+    console.ExitIf(seed.RunAll(model.DB))
+    for _, name := range names {
+        console.Success("Seeded: " + name)
+    }
+}
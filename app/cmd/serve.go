@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	middwares "goblog/app/http/middlewares"
+	"goblog/bootstrap"
+	"goblog/pkg/config"
+	"goblog/pkg/console"
+	"goblog/pkg/view"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdServe 启动 Web 服务
+var CmdServe = &cobra.Command{
+    Use:    "serve",
+    Short:  "Start the web server",
+    PreRun: setupDB,
+    Run:    runWeb,
+    Args:   cobra.NoArgs,
+}
+
+func runWeb(cmd *cobra.Command, args []string) {
+    if key := config.GetString("app.key"); len(key) == 0 {
+        console.Exit("APP_KEY 未设置，请先运行 key:generate 命令生成密钥")
+    } else if isPublicKey(key) {
+        console.Exit("APP_KEY 是公开的示例密钥，可被用来伪造会话和签名链接，请先运行 key:generate 命令重新生成")
+    }
+
+    router := bootstrap.SetupRoute()
+
+    // 预先解析并缓存所有模板，模板有语法错误时拒绝启动
+    console.ExitIf(view.Compile())
+
+    // 建立搜索索引
+    console.ExitIf(bootstrap.SetupSearch())
+
+    err := http.ListenAndServe(":"+config.GetString("app.port"), middwares.RemoveTrailingSlash(router))
+    console.ExitIf(err)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"goblog/app/models/role"
+	"goblog/app/models/user"
+	"goblog/app/requests"
+	"goblog/pkg/config"
+	"goblog/pkg/console"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdUserCreate 在命令行中创建用户
+var CmdUserCreate = &cobra.Command{
+    Use:    "user:create",
+    Short:  "Create a user account",
+    PreRun: setupDB,
+    Run:    runUserCreate,
+    Args:   cobra.NoArgs,
+}
+
+func init() {
+    CmdUserCreate.Flags().StringP("name", "n", "", "User name (required)")
+    CmdUserCreate.Flags().StringP("email", "e", "", "Email address (required)")
+    CmdUserCreate.Flags().StringP("password", "p", "", "Password (required)")
+    CmdUserCreate.Flags().StringSliceP("role", "r", nil, "Roles: admin, editor, author, reader (defaults to auth.default_role)")
+    CmdUserCreate.MarkFlagRequired("name")
+    CmdUserCreate.MarkFlagRequired("email")
+    CmdUserCreate.MarkFlagRequired("password")
+}
+
+func runUserCreate(cmd *cobra.Command, args []string) {
+    name, _ := cmd.Flags().GetString("name")
+    email, _ := cmd.Flags().GetString("email")
+    password, _ := cmd.Flags().GetString("password")
+    roles, _ := cmd.Flags().GetStringSlice("role")
+    if len(roles) == 0 {
+        roles = []string{config.GetString("auth.default_role")}
+    }
+
+    _user := user.User{
+        Name:            name,
+        Email:           email,
+        Password:        password,
+        PasswordConfirm: password,
+    }
+
+    // 与注册页面使用同样的表单验证规则
+    if errs := requests.ValidateRegistrationForm(_user); len(errs) > 0 {
+        fields := make([]string, 0, len(errs))
+        for field := range errs {
+            fields = append(fields, field)
+        }
+        sort.Strings(fields)
+        for _, field := range fields {
+            console.Error(fmt.Sprintf("%s: %s", field, strings.Join(errs[field], "；")))
+        }
+        console.Exit("创建用户失败")
+    }
+
+    // 先检查角色是否存在，避免创建出没有角色的用户
+    for _, name := range roles {
+        if _, err := role.GetByName(name); err != nil {
+            console.Exit("角色不存在：" + name)
+        }
+    }
+
+    // 由管理员在命令行创建的账号无需验证邮箱
+    verifiedAt := time.Now()
+    _user.EmailVerifiedAt = &verifiedAt
+
+    console.ExitIf(_user.Create())
+    console.ExitIf(role.Sync(_user.ID, roles...))
+    console.Success(fmt.Sprintf("User %s <%s> created, ID: %s, roles: %s", _user.Name, _user.Email, _user.GetStringID(), strings.Join(roles, ", ")))
+}
+
+// CmdUserRole 在命令行中设置用户的角色，例如指定第一个管理员
+var CmdUserRole = &cobra.Command{
+    Use:     "user:role <email> <role>...",
+    Short:   "Set the roles of a user",
+    Example: "  goblog user:role summer@example.com admin",
+    PreRun:  setupDB,
+    Run:     runUserRole,
+    Args:    cobra.MinimumNArgs(2),
+}
+
+func runUserRole(cmd *cobra.Command, args []string) {
+    _user, err := user.GetByEmail(args[0])
+    console.ExitIf(err)
+    console.ExitIf(role.Sync(_user.ID, args[1:]...))
+    console.Success(fmt.Sprintf("User %s <%s> roles: %s", _user.Name, _user.Email, strings.Join(args[1:], ", ")))
+}
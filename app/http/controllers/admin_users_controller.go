@@ -0,0 +1,104 @@
+package controllers
+
+import (
+    "goblog/app/models/role"
+    "goblog/app/models/user"
+    "goblog/pkg/auth"
+    "goblog/pkg/flash"
+    "goblog/pkg/route"
+    "goblog/pkg/view"
+    "net/http"
+)
+
+// AdminUsersController 用户管理控制器，需要 users.manage 权限
+type AdminUsersController struct {
+    BaseController
+}
+
+// userRow 用户列表中的一行，附带用户的角色名称
+type userRow struct {
+    user.User
+    RoleNames []string
+}
+
+// HasRole 用户是否拥有 name 角色，用于勾选角色复选框
+func (row userRow) HasRole(name string) bool {
+    return contains(row.RoleNames, name)
+}
+
+// Index 用户列表及各自的角色
+func (amc *AdminUsersController) Index(w http.ResponseWriter, r *http.Request) {
+    users, pagerData, err := user.Paginate(r, 20, "admin.users")
+    if err != nil {
+        amc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    roles, err := role.All()
+    if err != nil {
+        amc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    uids := make([]uint64, len(users))
+    for i, _user := range users {
+        uids[i] = _user.ID
+    }
+    names, err := role.NamesOfUsers(uids)
+    if err != nil {
+        amc.ResponseForServerError(w, r, err)
+        return
+    }
+    rows := make([]userRow, len(users))
+    for i, _user := range users {
+        rows[i] = userRow{User: _user, RoleNames: names[_user.ID]}
+    }
+
+    view.Render(w, r, view.D{
+        "Users":     rows,
+        "Roles":     roles,
+        "PagerData": pagerData,
+    }, "admin.users")
+}
+
+// UpdateRoles 设置用户的角色
+func (amc *AdminUsersController) UpdateRoles(w http.ResponseWriter, r *http.Request) {
+    _user, err := user.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        amc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    // 不能移除自己的管理员角色，避免站点失去管理员
+    r.ParseForm()
+    names := r.PostForm["roles"]
+    if _user.ID == auth.User(r).ID && !contains(names, role.Admin) {
+        current, err := role.NamesOf(_user.ID)
+        if err != nil {
+            amc.ResponseForServerError(w, r, err)
+            return
+        }
+        if contains(current, role.Admin) {
+            flash.Danger(r, "不能移除自己的管理员角色")
+            http.Redirect(w, r, route.RouteName2URL("admin.users"), http.StatusFound)
+            return
+        }
+    }
+
+    if err := role.Sync(_user.ID, names...); err != nil {
+        flash.Danger(r, err.Error())
+    } else {
+        flash.Success(r, "用户「"+_user.Name+"」的角色已更新")
+    }
+    http.Redirect(w, r, route.RouteName2URL("admin.users"), http.StatusFound)
+}
+
+// contains 字符串切片中是否包含 s
+func contains(list []string, s string) bool {
+    for _, item := range list {
+        if item == s {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,151 @@
+package v1
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/requests"
+    "goblog/pkg/auth"
+    "goblog/pkg/response"
+    "goblog/pkg/route"
+    "goblog/policies"
+    "net/http"
+    "strings"
+)
+
+// ArticlesController 文章接口
+type ArticlesController struct {
+    BaseAPIController
+}
+
+// articleInput 创建和修改文章的请求数据，修改时未提供的字段保持不变
+type articleInput struct {
+    Title      *string  `json:"title"`
+    Body       *string  `json:"body"`
+    CategoryID *uint64  `json:"category_id"`
+    Tags       []string `json:"tags"`
+}
+
+// fill 将请求数据写入文章
+func (in articleInput) fill(_article *article.Article) {
+    if in.Title != nil {
+        _article.Title = *in.Title
+    }
+    if in.Body != nil {
+        _article.Body = *in.Body
+    }
+    if in.CategoryID != nil {
+        _article.CategoryID = *in.CategoryID
+    }
+    if in.Tags != nil {
+        _article.TagNames = strings.Join(in.Tags, ",")
+    }
+}
+
+// Index 文章列表，分页数据在 meta 中
+func (ac *ArticlesController) Index(w http.ResponseWriter, r *http.Request) {
+    articles, pagerData, err := article.Paginate(r, 0, "api.v1.articles.index")
+    if err != nil {
+        ac.ResponseForSQLError(w, r, err)
+        return
+    }
+    response.JSON(w, http.StatusOK, map[string]interface{}{
+        "data": NewArticleResources(articles),
+        "meta": pagerData,
+    })
+}
+
+// Show 文章详情
+func (ac *ArticlesController) Show(w http.ResponseWriter, r *http.Request) {
+    _article, err := article.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        ac.ResponseForSQLError(w, r, err)
+        return
+    }
+    response.JSON(w, http.StatusOK, map[string]interface{}{
+        "data": NewArticleResource(_article),
+    })
+}
+
+// Store 创建文章
+func (ac *ArticlesController) Store(w http.ResponseWriter, r *http.Request) {
+    var in articleInput
+    if !ac.decodeJSON(w, r, &in) {
+        return
+    }
+
+    _article := article.Article{UserID: auth.User(r).ID}
+    in.fill(&_article)
+
+    if errors := requests.ValidateArticleForm(_article); len(errors) > 0 {
+        response.ValidationError(w, errors)
+        return
+    }
+    if err := _article.Create(); err != nil {
+        ac.ResponseForServerError(w, r, err)
+        return
+    }
+
+    // 重新读取以加载作者和分类
+    ac.respondWithArticle(w, r, http.StatusCreated, _article.GetStringID())
+}
+
+// Update 修改文章，只修改请求中提供的字段
+func (ac *ArticlesController) Update(w http.ResponseWriter, r *http.Request) {
+    _article, err := article.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        ac.ResponseForSQLError(w, r, err)
+        return
+    }
+    if !policies.CanModifyArticle(r, _article) {
+        ac.ResponseForUnauthorized(w, r)
+        return
+    }
+
+    var in articleInput
+    if !ac.decodeJSON(w, r, &in) {
+        return
+    }
+
+    // 未提供 tags 时保留原有标签
+    _article.TagNames = _article.TagList()
+    in.fill(&_article)
+
+    if errors := requests.ValidateArticleForm(_article); len(errors) > 0 {
+        response.ValidationError(w, errors)
+        return
+    }
+    if _, err := _article.Update(); err != nil {
+        ac.ResponseForServerError(w, r, err)
+        return
+    }
+    ac.respondWithArticle(w, r, http.StatusOK, _article.GetStringID())
+}
+
+// Delete 删除文章，成功时返回 204
+func (ac *ArticlesController) Delete(w http.ResponseWriter, r *http.Request) {
+    _article, err := article.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        ac.ResponseForSQLError(w, r, err)
+        return
+    }
+    if !policies.CanDeleteArticle(r, _article) {
+        ac.ResponseForUnauthorized(w, r)
+        return
+    }
+
+    if _, err := _article.Delete(); err != nil {
+        ac.ResponseForServerError(w, r, err)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (ac *ArticlesController) respondWithArticle(w http.ResponseWriter, r *http.Request, status int, id string) {
+    _article, err := article.Get(id)
+    if err != nil {
+        ac.ResponseForSQLError(w, r, err)
+        return
+    }
+    response.JSON(w, status, map[string]interface{}{
+        "data": NewArticleResource(_article),
+    })
+}
@@ -0,0 +1,45 @@
+// Package v1 第一版 JSON 接口，路由注册在 /api/v1 下
+package v1
+
+import (
+    "encoding/json"
+    "goblog/pkg/logger"
+    "goblog/pkg/response"
+    "net/http"
+
+    "go.uber.org/zap"
+    "gorm.io/gorm"
+)
+
+// BaseAPIController 接口控制器的基础控制器，错误均以 JSON 格式返回
+type BaseAPIController struct {
+}
+
+// ResponseForSQLError 处理 SQL 错误并返回
+func (bc BaseAPIController) ResponseForSQLError(w http.ResponseWriter, r *http.Request, err error) {
+    if err == gorm.ErrRecordNotFound {
+        response.Error(w, http.StatusNotFound, "资源不存在")
+    } else {
+        bc.ResponseForServerError(w, r, err)
+    }
+}
+
+// ResponseForServerError 记录错误日志并返回 500
+func (bc BaseAPIController) ResponseForServerError(w http.ResponseWriter, r *http.Request, err error) {
+    logger.WithContext(r.Context()).Error("服务器内部错误", zap.Error(err))
+    response.Error(w, http.StatusInternalServerError, "服务器内部错误，请稍后再试")
+}
+
+// ResponseForUnauthorized 处理未授权的访问
+func (bc BaseAPIController) ResponseForUnauthorized(w http.ResponseWriter, r *http.Request) {
+    response.Error(w, http.StatusForbidden, "您没有权限执行此操作")
+}
+
+// decodeJSON 解析 JSON 请求体，格式错误时返回 400 并返回 false
+func (bc BaseAPIController) decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+    if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+        response.Error(w, http.StatusBadRequest, "请求数据格式错误")
+        return false
+    }
+    return true
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/user"
+    "goblog/pkg/route"
+    "time"
+)
+
+// UserResource 接口返回的用户信息，不包含邮箱和密码
+type UserResource struct {
+    ID        uint64    `json:"id"`
+    Name      string    `json:"name"`
+    URL       string    `json:"url"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// CategoryResource 接口返回的分类信息
+type CategoryResource struct {
+    ID   uint64 `json:"id"`
+    Name string `json:"name"`
+}
+
+// ArticleResource 接口返回的文章信息，body 为 Markdown 原文，html 为渲染后的内容
+type ArticleResource struct {
+    ID        uint64            `json:"id"`
+    Title     string            `json:"title"`
+    Body      string            `json:"body"`
+    HTML      string            `json:"html"`
+    URL       string            `json:"url"`
+    Author    UserResource      `json:"author"`
+    Category  *CategoryResource `json:"category"`
+    Tags      []string          `json:"tags"`
+    CreatedAt time.Time         `json:"created_at"`
+    UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// NewUserResource 由用户模型生成接口数据
+func NewUserResource(u user.User) UserResource {
+    return UserResource{
+        ID:        u.ID,
+        Name:      u.Name,
+        URL:       route.URL(u.Link()),
+        CreatedAt: u.CreatedAt,
+    }
+}
+
+// NewArticleResource 由文章模型生成接口数据，未分类时 category 为 null
+func NewArticleResource(a article.Article) ArticleResource {
+    res := ArticleResource{
+        ID:        a.ID,
+        Title:     a.Title,
+        Body:      a.Body,
+        HTML:      string(a.HTML()),
+        URL:       route.URL(a.Link()),
+        Author:    NewUserResource(a.User),
+        Tags:      make([]string, len(a.Tags)),
+        CreatedAt: a.CreatedAt,
+        UpdatedAt: a.UpdatedAt,
+    }
+    if a.CategoryID > 0 {
+        res.Category = &CategoryResource{ID: a.Category.ID, Name: a.Category.Name}
+    }
+    for i, t := range a.Tags {
+        res.Tags[i] = t.Name
+    }
+    return res
+}
+
+// NewArticleResources 批量生成文章接口数据
+func NewArticleResources(articles []article.Article) []ArticleResource {
+    resources := make([]ArticleResource, len(articles))
+    for i, a := range articles {
+        resources[i] = NewArticleResource(a)
+    }
+    return resources
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+    "goblog/app/models/user"
+    "goblog/pkg/response"
+    "goblog/pkg/route"
+    "net/http"
+)
+
+// UsersController 用户接口
+type UsersController struct {
+    BaseAPIController
+}
+
+// Show 用户资料
+func (uc *UsersController) Show(w http.ResponseWriter, r *http.Request) {
+    _user, err := user.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        uc.ResponseForSQLError(w, r, err)
+        return
+    }
+    response.JSON(w, http.StatusOK, map[string]interface{}{
+        "data": NewUserResource(_user),
+    })
+}
@@ -1,11 +1,15 @@
 package controllers
 
 import (
-	"fmt"
 	"goblog/app/models/article"
+	"goblog/app/models/category"
+	"goblog/app/models/comment"
 	"goblog/app/requests"
 	"goblog/pkg/auth"
+	"goblog/pkg/flash"
+	"goblog/pkg/logger"
 	"goblog/pkg/route"
+	"goblog/pkg/types"
 	"goblog/pkg/view"
 	"goblog/policies"
 	"net/http"
@@ -22,11 +26,26 @@ func (ac *ArticlesController) Show(w http.ResponseWriter, r *http.Request) {
 	article, err := article.Get(id)
 
 	if err != nil {
-		ac.ResponseForSQLError(w, err)
+		ac.ResponseForSQLError(w, r, err)
 	} else {
-        view.Render(w, view.D{
+        // 读取评论，文章作者、管理员和编辑可以看到全部评论以便审核
+        canModerate := policies.CanModerateComments(r, article)
+        comments, err := comment.ForArticle(article.ID, auth.User(r).ID, canModerate)
+        if err != nil {
+            ac.ResponseForServerError(w, r, err)
+            return
+        }
+
+        view.Render(w, r, view.D{
             "Article": article,
-            "CanModifyArticle": policies.CanModifyArticle(article),
+            "CanModifyArticle": policies.CanModifyArticle(r, article),
+            "CommentsCount": len(comments),
+            "Thread": comment.Thread{
+                Comments:    comment.Tree(comments),
+                ArticleID:   article.GetStringID(),
+                CanReply:    auth.Check(r),
+                CanModerate: canModerate,
+            },
         }, "articles.show", "articles._article_meta")
 	}
 }
@@ -34,11 +53,14 @@ func (ac *ArticlesController) Show(w http.ResponseWriter, r *http.Request) {
 //list列表
 func (ac *ArticlesController) Index(w http.ResponseWriter, r *http.Request) {
 	//获取结果集
-	articles, err := article.GetAll()
+	articles, pagerData, err := article.GetAll(r, 0)
 	if err != nil {
-		ac.ResponseForSQLError(w, err)
+		ac.ResponseForSQLError(w, r, err)
 	} else {
-		view.Render(w, view.D{"Articles":articles}, "articles.index", "articles._article_meta")
+		view.Render(w, r, view.D{
+			"Articles":  articles,
+			"PagerData": pagerData,
+		}, "articles.index", "articles._article_meta")
 	}
 }
 
@@ -47,16 +69,18 @@ func (ac *ArticlesController) Edit(w http.ResponseWriter, r *http.Request) {
 	id := route.GetRouterParam("id", r)
 	article, err := article.Get(id)
 	if err != nil {
-		ac.ResponseForSQLError(w, err)
+		ac.ResponseForSQLError(w, r, err)
 	} else {
         // 检查权限
-        if !policies.CanModifyArticle(article) {
+        if !policies.CanModifyArticle(r, article) {
             ac.ResponseForUnauthorized(w, r)
         } else {
             // 4. 读取成功，显示编辑文章表单
-            view.Render(w, view.D{
-                "Article": article,
-                "Errors":  view.D{},
+            article.TagNames = article.TagList()
+            view.Render(w, r, view.D{
+                "Article":    article,
+                "Categories": formCategories(),
+                "Errors":     view.D{},
             }, "articles.edit", "articles._form_field")
         }
 	}
@@ -68,35 +92,38 @@ func (ac *ArticlesController) Update(w http.ResponseWriter, r *http.Request) {
 	_article, err := article.Get(id)
 
 	if err != nil {
-        ac.ResponseForSQLError(w, err)
+        ac.ResponseForSQLError(w, r, err)
     } else {
         // 检查权限
-        if !policies.CanModifyArticle(_article) {
+        if !policies.CanModifyArticle(r, _article) {
             ac.ResponseForUnauthorized(w, r)
         } else {
             _article.Title = r.PostFormValue("title")
 			_article.Body = r.PostFormValue("body")
+            _article.CategoryID = types.StringToUint64(r.PostFormValue("category_id"))
+            _article.TagNames = r.PostFormValue("tags")
             
             errors := requests.ValidateArticleForm(_article)
             if len(errors) == 0 {
                 rowsAffected, err := _article.Update()
                 if err != nil {
-                    w.WriteHeader(http.StatusInternalServerError)
-                    fmt.Fprint(w, "500 服务器内部错误")
+                    ac.ResponseForServerError(w, r, err)
                     return
                 }
     
                 if rowsAffected > 0 {
-                    showUrl := route.RouteName2URL("articles.show", "id", id)
-                    http.Redirect(w, r, showUrl, http.StatusFound)
+                    flash.Success(r, "文章更新成功！")
                 } else {
-                    fmt.Fprint(w, "您没有做任何更改！")
+                    flash.Info(r, "您没有做任何更改！")
                 }
+                showUrl := route.RouteName2URL("articles.show", "id", id)
+                http.Redirect(w, r, showUrl, http.StatusFound)
             } else {
                 // 4.3 表单验证不通过，显示理由
-                view.Render(w, view.D{
-                    "Article": _article,
-                    "Errors":  errors,
+                view.Render(w, r, view.D{
+                    "Article":    _article,
+                    "Categories": formCategories(),
+                    "Errors":     errors,
                 }, "articles.edit", "articles._form_field")
             }
         }
@@ -106,17 +133,23 @@ func (ac *ArticlesController) Update(w http.ResponseWriter, r *http.Request) {
 
 // Create 文章创建页面
 func (*ArticlesController) Create(w http.ResponseWriter, r *http.Request) {
-    view.Render(w, view.D{}, "articles.create", "articles._form_field")
+    view.Render(w, r, view.D{
+        "Article":    article.Article{},
+        "Categories": formCategories(),
+    }, "articles.create", "articles._form_field")
 }
 
 // Store 文章创建页面
-func (*ArticlesController) Store(w http.ResponseWriter, r *http.Request) {
+func (ac *ArticlesController) Store(w http.ResponseWriter, r *http.Request) {
     // 1. 初始化数据
-    currentUser := auth.User()
+    currentUser := auth.User(r)
     _article := article.Article{
         Title:  r.PostFormValue("title"),
         Body:   r.PostFormValue("body"),
         UserID: currentUser.ID,
+
+        CategoryID: types.StringToUint64(r.PostFormValue("category_id")),
+        TagNames:   r.PostFormValue("tags"),
     }
     // 2. 表单验证
     errors := requests.ValidateArticleForm(_article)
@@ -124,18 +157,17 @@ func (*ArticlesController) Store(w http.ResponseWriter, r *http.Request) {
     // 3. 检测错误
     if len(errors) == 0 {
         // 创建文章
-        _article.Create()
-        if _article.ID > 0 {
-            indexURL := route.RouteName2URL("articles.show", "id", _article.GetStringID())
-            http.Redirect(w, r, indexURL, http.StatusFound)
-        } else {
-            w.WriteHeader(http.StatusInternalServerError)
-            fmt.Fprint(w, "创建文章失败，请联系管理员")
+        if err := _article.Create(); err != nil {
+            ac.ResponseForServerError(w, r, err)
+            return
         }
+        indexURL := route.RouteName2URL("articles.show", "id", _article.GetStringID())
+        http.Redirect(w, r, indexURL, http.StatusFound)
     } else {
-        view.Render(w, view.D{
-            "Article": _article,
-            "Errors":  errors,
+        view.Render(w, r, view.D{
+            "Article":    _article,
+            "Categories": formCategories(),
+            "Errors":     errors,
         }, "articles.create", "articles._form_field")
     }
 }
@@ -151,10 +183,10 @@ func (ac *ArticlesController) Delete(w http.ResponseWriter, r *http.Request) {
 
     // 3. 如果出现错误
     if err != nil {
-        ac.ResponseForSQLError(w, err)
+        ac.ResponseForSQLError(w, r, err)
     } else {
         // 检查权限
-        if !policies.CanModifyArticle(_article) {
+        if !policies.CanDeleteArticle(r, _article) {
             ac.ResponseForUnauthorized(w, r)
         } else {
             // 4. 未出现错误，执行删除操作
@@ -163,21 +195,27 @@ func (ac *ArticlesController) Delete(w http.ResponseWriter, r *http.Request) {
         // 4.1 发生错误
         if err != nil {
             // 应该是 SQL 报错了
-            w.WriteHeader(http.StatusInternalServerError)
-            fmt.Fprint(w, "500 服务器内部错误")
+            ac.ResponseForServerError(w, r, err)
         } else {
             // 4.2 未发生错误
             if rowsAffected > 0 {
-                // 重定向到文章列表页
-                indexURL := route.RouteName2URL("articles.index")
+                // 重定向到首页的文章列表
+                flash.Success(r, "文章已删除")
+                indexURL := route.RouteName2URL("home")
                 http.Redirect(w, r, indexURL, http.StatusFound)
             } else {
                 // Edge case
-                w.WriteHeader(http.StatusNotFound)
-                fmt.Fprint(w, "404 文章未找到")
+                view.RenderError(w, r, http.StatusNotFound, "")
             }
         }
         }
 
     }
-}
\ No newline at end of file
+}
+
+// formCategories 文章表单中可选的分类，读取失败时只记录日志，表单仍可提交为未分类
+func formCategories() []category.Category {
+    categories, err := category.All()
+    logger.LogError(err)
+    return categories
+}
@@ -1,26 +1,30 @@
 package controllers
 
 import (
-	"fmt"
+	"goblog/app/models/role"
 	"goblog/app/models/user"
 	"goblog/app/requests"
 	"goblog/pkg/auth"
 	"goblog/pkg/flash"
+	"goblog/pkg/logger"
+	"goblog/pkg/route"
 	"goblog/pkg/view"
 	"net/http"
 )
 
 
-type AuthController struct{}
+type AuthController struct{
+    BaseController
+}
 
 // Register 注册页面
 func (*AuthController) Register(w http.ResponseWriter, r *http.Request) {
-	view.RenderSimple(w, view.D{}, "auth.register")
+	view.RenderSimple(w, r, view.D{}, "auth.register")
 }
 
 
 // DoRegister 处理注册逻辑
-func (*AuthController) DoRegister(w http.ResponseWriter, r *http.Request) {
+func (ac *AuthController) DoRegister(w http.ResponseWriter, r *http.Request) {
 	// 1. 初始化数据
     _user := user.User{
         Name:            r.PostFormValue("name"),
@@ -33,29 +37,34 @@ func (*AuthController) DoRegister(w http.ResponseWriter, r *http.Request) {
     errs := requests.ValidateRegistrationForm(_user)
 
     if len(errs) > 0 {
-		view.RenderSimple(w, view.D{
+		view.RenderSimple(w, r, view.D{
             "Errors": errs,
             "User":   _user,
         }, "auth.register")
     } else {
         // 4. 验证成功，创建数据
-        _user.Create()
+        if err := _user.Create(); err != nil {
+            ac.ResponseForServerError(w, r, err)
+            return
+        }
+        // 分配默认角色，失败时仅记录日志，可由管理员在用户管理页面中补上
+        logger.LogError(role.AssignDefault(_user.ID))
 
-        if _user.ID > 0 {
-			auth.Login(_user)
-			// 登录用户并跳转到首页
-            flash.Success("恭喜您注册成功！")
-            http.Redirect(w, r, "/", http.StatusFound)
+		// 5. 发送验证邮件，登录用户并提示验证邮箱
+        auth.Login(r, _user)
+        if err := sendVerificationEmail(_user); err != nil {
+            logger.LogError(err)
+            flash.Warning(r, "恭喜您注册成功！验证邮件发送失败，请稍后重新发送")
         } else {
-            w.WriteHeader(http.StatusInternalServerError)
-            fmt.Fprint(w, "注册失败，请联系管理员")
+            flash.Success(r, "恭喜您注册成功！验证邮件已发送到 "+_user.Email)
         }
+        http.Redirect(w, r, route.RouteName2URL("verification.notice"), http.StatusFound)
     }
 }
 
 // Login 显示登录表单
 func (*AuthController) Login(w http.ResponseWriter, r *http.Request) {
-    view.RenderSimple(w, view.D{}, "auth.login")
+    view.RenderSimple(w, r, view.D{}, "auth.login")
 }
 
 // DoLogin 处理登录表单提交
@@ -65,23 +74,25 @@ func (*AuthController) DoLogin(w http.ResponseWriter, r *http.Request) {
     password := r.PostFormValue("password")
 
 	// 2. 尝试登录
-    if err := auth.Attempt(email, password); err == nil {
+    remember := r.PostFormValue("remember") == "on"
+    if err := auth.Attempt(r, email, password, remember); err == nil {
         // 登录成功
-		flash.Success("欢迎回来！")
+		flash.Success(r, "欢迎回来！")
         http.Redirect(w, r, "/", http.StatusFound)
     } else {
         // 3. 失败，显示错误提示
-        view.RenderSimple(w, view.D{
+        view.RenderSimple(w, r, view.D{
             "Error":    err.Error(),
             "Email":    email,
             "Password": password,
+            "Remember": remember,
         }, "auth.login")
     }
 }
 
 // Logout 退出登录
 func (*AuthController) Logout(w http.ResponseWriter, r *http.Request) {
-    auth.Logout()
-	flash.Success("您已退出登录")
+    auth.Logout(r)
+	flash.Success(r, "您已退出登录")
     http.Redirect(w, r, "/", http.StatusFound)
 }
\ No newline at end of file
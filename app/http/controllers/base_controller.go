@@ -1,11 +1,11 @@
 package controllers
 
 import (
-    "fmt"
-    "goblog/pkg/flash"
     "goblog/pkg/logger"
+    "goblog/pkg/view"
     "net/http"
 
+    "go.uber.org/zap"
     "gorm.io/gorm"
 )
 
@@ -14,21 +14,23 @@ type BaseController struct {
 }
 
 // ResponseForSQLError 处理 SQL 错误并返回
-func (bc BaseController) ResponseForSQLError(w http.ResponseWriter, err error) {
+func (bc BaseController) ResponseForSQLError(w http.ResponseWriter, r *http.Request, err error) {
     if err == gorm.ErrRecordNotFound {
         // 3.1 数据未找到
-        w.WriteHeader(http.StatusNotFound)
-        fmt.Fprint(w, "404 文章未找到")
+        view.RenderError(w, r, http.StatusNotFound, "")
     } else {
         // 3.2 数据库错误
-        logger.LogError(err)
-        w.WriteHeader(http.StatusInternalServerError)
-        fmt.Fprint(w, "500 服务器内部错误")
+        bc.ResponseForServerError(w, r, err)
     }
 }
 
+// ResponseForServerError 记录错误日志并显示 500 页面
+func (bc BaseController) ResponseForServerError(w http.ResponseWriter, r *http.Request, err error) {
+    logger.WithContext(r.Context()).Error("服务器内部错误", zap.Error(err))
+    view.RenderError(w, r, http.StatusInternalServerError, "")
+}
+
 // ResponseForUnauthorized 处理未授权的访问
 func (bc BaseController) ResponseForUnauthorized(w http.ResponseWriter, r *http.Request) {
-    flash.Warning("未授权操作！")
-    http.Redirect(w, r, "/", http.StatusFound)
-}
\ No newline at end of file
+    view.RenderError(w, r, http.StatusForbidden, "")
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/category"
+    "goblog/pkg/route"
+    "goblog/pkg/view"
+    "net/http"
+)
+
+// CategoriesController 文章分类控制器
+type CategoriesController struct {
+    BaseController
+}
+
+// Show 显示分类下的文章列表
+func (cc *CategoriesController) Show(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 获取 URL 参数
+    id := route.GetRouterParam("id", r)
+
+    // 2. 读取对应的分类
+    _category, err := category.Get(id)
+
+    // 3. 如果出现错误
+    if err != nil {
+        cc.ResponseForSQLError(w, r, err)
+    } else {
+        // 4. 读取成功，显示分类下的文章
+        articles, pagerData, err := article.GetByCategoryID(r, _category.GetStringID(), 0)
+        if err != nil {
+            cc.ResponseForServerError(w, r, err)
+        } else {
+            view.Render(w, r, view.D{
+                "Category":  _category,
+                "Articles":  articles,
+                "PagerData": pagerData,
+            }, "categories.show")
+        }
+    }
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/comment"
+    "goblog/app/requests"
+    "goblog/pkg/auth"
+    "goblog/pkg/flash"
+    "goblog/pkg/route"
+    "goblog/pkg/types"
+    "goblog/policies"
+    "net/http"
+    "strings"
+)
+
+// CommentsController 文章评论控制器
+type CommentsController struct {
+    BaseController
+}
+
+// Store 发表评论或回复
+func (cc *CommentsController) Store(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 读取评论的文章
+    id := route.GetRouterParam("id", r)
+    _article, err := article.Get(id)
+    if err != nil {
+        cc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    // 2. 初始化数据，文章作者的评论无需审核
+    currentUser := auth.User(r)
+    _comment := comment.Comment{
+        ArticleID: _article.ID,
+        UserID:    currentUser.ID,
+        ParentID:  types.StringToUint64(r.PostFormValue("parent_id")),
+        Body:      strings.TrimSpace(r.PostFormValue("body")),
+        Status:    comment.StatusPending,
+    }
+    if policies.CanModerateComments(r, _article) {
+        _comment.Status = comment.StatusApproved
+    }
+
+    // 3. 表单验证，评论表单在文章页中，验证不通过时以消息提示
+    errors := requests.ValidateCommentForm(_comment)
+    if len(errors) > 0 {
+        for _, field := range []string{"body", "parent_id"} {
+            if errs := errors[field]; len(errs) > 0 {
+                flash.Danger(r, errs[0])
+                break
+            }
+        }
+        http.Redirect(w, r, _article.Link()+"#comments", http.StatusFound)
+        return
+    }
+
+    // 4. 创建评论
+    if err := _comment.Create(); err != nil {
+        cc.ResponseForServerError(w, r, err)
+        return
+    }
+    if _comment.IsPending() {
+        flash.Info(r, "评论已提交，审核通过后将公开显示")
+    } else {
+        flash.Success(r, "评论发表成功！")
+    }
+    http.Redirect(w, r, _article.Link()+"#comment-"+_comment.GetStringID(), http.StatusFound)
+}
+
+// Approve 审核通过评论
+func (cc *CommentsController) Approve(w http.ResponseWriter, r *http.Request) {
+    cc.moderate(w, r, "评论已通过审核", func(_comment *comment.Comment) error {
+        return _comment.SetStatus(comment.StatusApproved)
+    })
+}
+
+// Spam 将评论标记为垃圾评论
+func (cc *CommentsController) Spam(w http.ResponseWriter, r *http.Request) {
+    cc.moderate(w, r, "评论已标记为垃圾评论", func(_comment *comment.Comment) error {
+        return _comment.SetStatus(comment.StatusSpam)
+    })
+}
+
+// Delete 删除评论及其回复
+func (cc *CommentsController) Delete(w http.ResponseWriter, r *http.Request) {
+    cc.moderate(w, r, "评论已删除", func(_comment *comment.Comment) error {
+        _, err := _comment.Delete()
+        return err
+    })
+}
+
+// moderate 读取评论并检查审核权限，通过后执行 action，成功后提示 message 并返回文章页
+func (cc *CommentsController) moderate(w http.ResponseWriter, r *http.Request, message string, action func(_comment *comment.Comment) error) {
+
+    // 1. 读取评论和所属文章
+    _comment, err := comment.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        cc.ResponseForSQLError(w, r, err)
+        return
+    }
+    _article, err := article.Get(types.Uint64ToString(_comment.ArticleID))
+    if err != nil {
+        cc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    // 2. 检查权限
+    if !policies.CanModerateComments(r, _article) {
+        cc.ResponseForUnauthorized(w, r)
+        return
+    }
+
+    // 3. 执行操作
+    if err := action(&_comment); err != nil {
+        cc.ResponseForServerError(w, r, err)
+        return
+    }
+    flash.Success(r, message)
+    http.Redirect(w, r, _article.Link()+"#comments", http.StatusFound)
+}
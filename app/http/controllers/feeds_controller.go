@@ -0,0 +1,126 @@
+package controllers
+
+import (
+    "bytes"
+    "goblog/app/models/article"
+    "goblog/app/models/user"
+    "goblog/pkg/config"
+    "goblog/pkg/route"
+    "net/http"
+    "time"
+
+    "github.com/gorilla/feeds"
+)
+
+// feedLimit 订阅源中的文章数
+const feedLimit = 20
+
+// FeedsController 订阅源，支持 RSS 2.0、Atom 和 JSON Feed
+type FeedsController struct {
+    BaseController
+}
+
+// RSS 全站 RSS 2.0 订阅源
+func (fc *FeedsController) RSS(w http.ResponseWriter, r *http.Request) {
+    fc.serveSiteFeed(w, r, "application/rss+xml; charset=utf-8", func(feed *feeds.Feed) (string, error) {
+        return feed.ToRss()
+    })
+}
+
+// Atom 全站 Atom 订阅源
+func (fc *FeedsController) Atom(w http.ResponseWriter, r *http.Request) {
+    fc.serveSiteFeed(w, r, "application/atom+xml; charset=utf-8", func(feed *feeds.Feed) (string, error) {
+        return feed.ToAtom()
+    })
+}
+
+// JSON 全站 JSON Feed 订阅源
+func (fc *FeedsController) JSON(w http.ResponseWriter, r *http.Request) {
+    fc.serveSiteFeed(w, r, "application/feed+json; charset=utf-8", func(feed *feeds.Feed) (string, error) {
+        jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+        jsonFeed.FeedUrl = route.URL(route.RouteName2URL("feeds.json"))
+        return jsonFeed.ToJSON()
+    })
+}
+
+// User 用户的 RSS 2.0 订阅源
+func (fc *FeedsController) User(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 读取用户
+    id := route.GetRouterParam("id", r)
+    _user, err := user.Get(id)
+    if err != nil {
+        fc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    // 2. 读取用户最新的文章
+    articles, err := article.GetRecent(_user.GetStringID(), feedLimit)
+    if err != nil {
+        fc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    // 3. 生成订阅源
+    feed := newFeed(_user.Name+" - "+config.GetString("app.name"), _user.Link(), articles)
+    body, err := feed.ToRss()
+    if err != nil {
+        fc.ResponseForServerError(w, r, err)
+        return
+    }
+    serveFeed(w, r, "application/rss+xml; charset=utf-8", feed.Updated, body)
+}
+
+// serveSiteFeed 生成全站订阅源，render 负责输出具体的格式
+func (fc *FeedsController) serveSiteFeed(w http.ResponseWriter, r *http.Request, contentType string, render func(feed *feeds.Feed) (string, error)) {
+    articles, err := article.GetRecent("", feedLimit)
+    if err != nil {
+        fc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    feed := newFeed(config.GetString("app.name"), route.RouteName2URL("home"), articles)
+    body, err := render(feed)
+    if err != nil {
+        fc.ResponseForServerError(w, r, err)
+        return
+    }
+    serveFeed(w, r, contentType, feed.Updated, body)
+}
+
+// newFeed 由文章生成订阅源，链接均为绝对链接，订阅源的更新时间取文章中最新的 UpdatedAt
+func newFeed(title string, link string, articles []article.Article) *feeds.Feed {
+    feed := &feeds.Feed{
+        Title:       title,
+        Link:        &feeds.Link{Href: route.URL(link)},
+        Description: title,
+        Id:          route.URL(link),
+    }
+
+    for _, a := range articles {
+        url := route.URL(a.Link())
+        feed.Add(&feeds.Item{
+            Title:       a.Title,
+            Link:        &feeds.Link{Href: url},
+            Id:          url,
+            Author:      &feeds.Author{Name: a.User.Name},
+            Description: a.Excerpt(200),
+            Content:     string(a.HTML()),
+            Created:     a.CreatedAt,
+            Updated:     a.UpdatedAt,
+        })
+        if a.UpdatedAt.After(feed.Updated) {
+            feed.Updated = a.UpdatedAt
+        }
+    }
+    if feed.Updated.IsZero() {
+        feed.Updated = time.Now()
+    }
+    return feed
+}
+
+// serveFeed 输出订阅源，支持 If-Modified-Since 条件请求，订阅源未更新时返回 304
+func serveFeed(w http.ResponseWriter, r *http.Request, contentType string, updated time.Time, body string) {
+    w.Header().Set("Content-Type", contentType)
+    http.ServeContent(w, r, "", updated, bytes.NewReader([]byte(body)))
+}
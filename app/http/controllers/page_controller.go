@@ -1,23 +1,19 @@
 package controllers
 
 import (
-	"fmt"
+	"goblog/pkg/view"
 	"net/http"
 )
 
 // PagesController 处理静态页面
 type PackageController struct{}
 
-func (*PackageController) Home(w http.ResponseWriter, r *http.Request) {
-    fmt.Fprint(w, "<h1>Hello, 欢迎来到 goblog！</h1>")
-}
-
+// About 关于我们页面
 func (*PackageController) About(w http.ResponseWriter, r *http.Request) {
-    fmt.Fprint(w, "此博客是用以记录编程笔记，如您有反馈或建议，请联系 "+
-        "<a href=\"mailto:sreio@example.com\">sreio@example.com</a>")
+    view.Render(w, r, view.D{}, "pages.about")
 }
 
+// NotFound 404 页面
 func (*PackageController) NotFound(w http.ResponseWriter, r *http.Request) {
-    w.WriteHeader(http.StatusNotFound)
-    fmt.Fprint(w, "<h1>请求页面未找到 :(</h1><p>如有疑惑，请联系我们。</p>")
-}
\ No newline at end of file
+    view.RenderError(w, r, http.StatusNotFound, "")
+}
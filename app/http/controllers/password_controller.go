@@ -0,0 +1,198 @@
+package controllers
+
+import (
+    "fmt"
+    "goblog/app/models/passwordreset"
+    "goblog/app/models/user"
+    "goblog/app/requests"
+    "goblog/pkg/config"
+    "goblog/pkg/flash"
+    "goblog/pkg/logger"
+    "goblog/pkg/mail"
+    "goblog/pkg/realip"
+    "goblog/pkg/route"
+    "goblog/pkg/session"
+    "goblog/pkg/throttle"
+    "goblog/pkg/types"
+    "goblog/pkg/view"
+    "html/template"
+    "math"
+    "net/http"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// PasswordController 找回密码控制器
+type PasswordController struct {
+    BaseController
+}
+
+// ShowForgotForm 申请重置密码页面
+func (*PasswordController) ShowForgotForm(w http.ResponseWriter, r *http.Request) {
+    view.RenderSimple(w, r, view.D{}, "auth.forgot")
+}
+
+// SendResetLink 发送重置密码链接。无论邮箱是否注册都显示相同的提示，避免泄露注册信息
+func (pc *PasswordController) SendResetLink(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 表单验证
+    email := strings.TrimSpace(r.PostFormValue("email"))
+    if errs := requests.ValidatePasswordForgotForm(user.User{Email: email}); len(errs) > 0 {
+        view.RenderSimple(w, r, view.D{
+            "Email":  email,
+            "Errors": errs,
+        }, "auth.forgot")
+        return
+    }
+
+    // 2. 限制发送频率，无论邮箱是否注册都计数
+    if wait, throttled := throttleResetLink(r, email); throttled {
+        flash.Danger(r, fmt.Sprintf("操作过于频繁，请在 %d 秒后重试", int(math.Ceil(wait.Seconds()))))
+        http.Redirect(w, r, route.RouteName2URL("auth.password.request"), http.StatusFound)
+        return
+    }
+
+    // 3. 用户存在时生成令牌并发送邮件，发送失败时只记录日志，提示与未注册时相同
+    _user, err := user.GetByEmail(email)
+    if err != nil && err != gorm.ErrRecordNotFound {
+        pc.ResponseForServerError(w, r, err)
+        return
+    }
+    if err == nil {
+        plainText, err := passwordreset.Create(_user.ID)
+        if err != nil {
+            pc.ResponseForServerError(w, r, err)
+            return
+        }
+        link := route.URL(route.RouteName2URL("auth.password.reset", "token", plainText))
+        logger.LogError(mail.Send(resetLinkMessage(_user, link)))
+    }
+
+    flash.Success(r, "如果该邮箱已注册，重置密码的链接已发送，请查收邮件")
+    http.Redirect(w, r, route.RouteName2URL("auth.password.request"), http.StatusFound)
+}
+
+// ShowResetForm 设置新密码页面，令牌无效时返回申请页面
+func (pc *PasswordController) ShowResetForm(w http.ResponseWriter, r *http.Request) {
+    token := route.GetRouterParam("token", r)
+    if _, ok := pc.findReset(w, r, token); !ok {
+        return
+    }
+    view.RenderSimple(w, r, view.D{
+        "Token": token,
+    }, "auth.reset")
+}
+
+// Reset 设置新密码，成功后令牌失效，并注销该用户的所有会话和“记住我”
+func (pc *PasswordController) Reset(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 校验令牌
+    token := r.PostFormValue("token")
+    reset, ok := pc.findReset(w, r, token)
+    if !ok {
+        return
+    }
+    _user, err := user.Get(types.Uint64ToString(reset.UserID))
+    if err != nil {
+        pc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    // 2. 表单验证
+    _user.Password = r.PostFormValue("password")
+    _user.PasswordConfirm = r.PostFormValue("password_confirm")
+    if errs := requests.ValidatePasswordResetForm(_user); len(errs) > 0 {
+        view.RenderSimple(w, r, view.D{
+            "Token":  token,
+            "Errors": errs,
+        }, "auth.reset")
+        return
+    }
+
+    // 3. 保存新密码，由 BeforeSave 钩子加密并使“记住我”失效
+    if _, err := _user.Update(); err != nil {
+        pc.ResponseForServerError(w, r, err)
+        return
+    }
+    if err := passwordreset.DeleteByUserID(_user.ID); err != nil {
+        pc.ResponseForServerError(w, r, err)
+        return
+    }
+    if err := session.RevokeUser(_user.GetStringID()); err != nil && err != session.ErrNotSupported {
+        logger.LogError(err)
+    }
+
+    flash.Success(r, "密码已重置，请使用新密码登录")
+    http.Redirect(w, r, route.RouteName2URL("auth.login"), http.StatusFound)
+}
+
+// findReset 查找有效的重置令牌，无效时提示并跳转到申请页面
+func (pc *PasswordController) findReset(w http.ResponseWriter, r *http.Request, token string) (passwordreset.PasswordReset, bool) {
+    reset, err := passwordreset.FindValid(token)
+    if err == nil {
+        return reset, true
+    }
+    if err != gorm.ErrRecordNotFound {
+        pc.ResponseForServerError(w, r, err)
+        return reset, false
+    }
+    flash.Danger(r, "重置链接无效或已过期，请重新申请")
+    http.Redirect(w, r, route.RouteName2URL("auth.password.request"), http.StatusFound)
+    return reset, false
+}
+
+// throttleResetLink 记录一次申请，同一 Email 或 IP 超过次数上限时返回需等待的时间。
+// 计数存储出错时不阻止申请
+func throttleResetLink(r *http.Request, email string) (time.Duration, bool) {
+    window := time.Duration(config.GetInt("throttle.password_reset.window", 3600)) * time.Second
+    limiters := map[string]*throttle.Limiter{
+        "password-reset:email:" + strings.ToLower(email): {
+            Store:       throttle.DefaultStore(),
+            MaxAttempts: config.GetInt("throttle.password_reset.max_attempts", 3),
+            Lockout:     window,
+        },
+        "password-reset:ip:" + realip.FromRequest(r): {
+            Store:       throttle.DefaultStore(),
+            MaxAttempts: config.GetInt("throttle.password_reset.max_attempts_per_ip", 10),
+            Lockout:     window,
+        },
+    }
+
+    var wait time.Duration
+    throttled := false
+    for key, limiter := range limiters {
+        attempts, _, err := limiter.Hit(key)
+        if err != nil {
+            logger.LogError(err)
+            continue
+        }
+        if limiter.TooMany(attempts) {
+            throttled = true
+            if available, _ := limiter.AvailableIn(key); available > wait {
+                wait = available
+            }
+        }
+    }
+    return wait, throttled
+}
+
+// resetLinkMessage 重置密码邮件
+func resetLinkMessage(_user user.User, link string) mail.Message {
+    appName := config.GetString("app.name")
+    expire := config.GetInt("auth.password_reset_expire", 60)
+
+    text := fmt.Sprintf("%s，您好：\n\n我们收到了重置您在 %s 的密码的请求，请访问以下链接设置新密码：\n\n%s\n\n"+
+        "链接将在 %d 分钟后失效。如果这不是您本人的操作，请忽略此邮件。\n", _user.Name, appName, link, expire)
+    html := fmt.Sprintf("<p>%s，您好：</p><p>我们收到了重置您在 %s 的密码的请求，请点击以下链接设置新密码：</p>"+
+        `<p><a href="%s">重置密码</a></p><p>链接将在 %d 分钟后失效。如果这不是您本人的操作，请忽略此邮件。</p>`,
+        template.HTMLEscapeString(_user.Name), template.HTMLEscapeString(appName), template.HTMLEscapeString(link), expire)
+
+    return mail.Message{
+        To:      []mail.Address{{Address: _user.Email, Name: _user.Name}},
+        Subject: "重置密码 - " + appName,
+        Text:    text,
+        HTML:    html,
+    }
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/user"
+    "goblog/pkg/pagination"
+    "goblog/pkg/search"
+    "goblog/pkg/view"
+    "net/http"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// SearchController 文章搜索
+type SearchController struct {
+    BaseController
+}
+
+// dateLayout 日期过滤参数的格式
+const dateLayout = "2006-01-02"
+
+// Index 搜索结果页，支持 q、author（用户名）、from 和 to（发布日期）参数
+func (sc *SearchController) Index(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 读取搜索条件
+    params := r.URL.Query()
+    keywords := strings.TrimSpace(params.Get("q"))
+    author := strings.TrimSpace(params.Get("author"))
+    query := search.Query{Keywords: keywords}
+    errors := map[string]string{}
+
+    if author != "" {
+        _user, err := user.GetByName(author)
+        if err != nil && err != gorm.ErrRecordNotFound {
+            sc.ResponseForServerError(w, r, err)
+            return
+        }
+        if err == gorm.ErrRecordNotFound {
+            errors["author"] = "作者不存在"
+        }
+        query.UserID = _user.ID
+    }
+    if from := params.Get("from"); from != "" {
+        t, err := time.ParseInLocation(dateLayout, from, time.Local)
+        if err != nil {
+            errors["from"] = "日期格式应为 2006-01-02"
+        }
+        query.From = t
+    }
+    if to := params.Get("to"); to != "" {
+        t, err := time.ParseInLocation(dateLayout, to, time.Local)
+        if err != nil {
+            errors["to"] = "日期格式应为 2006-01-02"
+        } else {
+            // 包含结束日期当天
+            query.To = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+        }
+    }
+
+    data := view.D{
+        "SearchKeywords": keywords,
+        "Author":         author,
+        "From":           params.Get("from"),
+        "To":             params.Get("to"),
+        "Errors":         errors,
+    }
+
+    // 2. 条件有误或未输入关键词时只显示搜索表单
+    if keywords == "" || len(errors) > 0 {
+        view.Render(w, r, data, "search.index")
+        return
+    }
+
+    // 3. 搜索并分页，总数由搜索引擎返回
+    _pager := pagination.New(r, nil, 0, "search")
+    query.Offset = _pager.Offset()
+    query.Limit = _pager.PerPage
+    result, err := search.Search(query)
+    if err != nil {
+        sc.ResponseForServerError(w, r, err)
+        return
+    }
+    articles, err := article.GetByIDs(result.IDs)
+    if err != nil {
+        sc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    data["Searched"] = true
+    data["Articles"] = articles
+    data["PagerData"] = _pager.WithTotal(result.Total).Paging()
+    view.Render(w, r, data, "search.index")
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/user"
+    "goblog/pkg/config"
+    "goblog/pkg/logger"
+    "goblog/pkg/route"
+    "goblog/pkg/sitemap"
+    "goblog/pkg/types"
+    "goblog/pkg/view"
+    "net/http"
+    "strings"
+
+    "github.com/spf13/cast"
+)
+
+// SitemapController 站点地图和 robots.txt
+type SitemapController struct {
+    BaseController
+}
+
+// sitemapSource 站点地图的一类链接，按顺序分段读取
+type sitemapSource struct {
+    count func() (int64, error)
+    urls  func(offset int, limit int) ([]sitemap.URL, error)
+}
+
+// Index 链接数不超过 sitemap.max_urls 时输出站点地图，否则输出站点地图索引
+func (sc *SitemapController) Index(w http.ResponseWriter, r *http.Request) {
+    total, err := sc.total()
+    if err != nil {
+        sc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    max := sc.maxURLs()
+    w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+    if total <= int64(max) {
+        urls, err := sc.urls(0, int(total))
+        if err != nil {
+            sc.ResponseForServerError(w, r, err)
+            return
+        }
+        // 已开始输出响应，写入失败时只能记录日志
+        logger.LogError(sitemap.WriteURLSet(w, urls))
+        return
+    }
+
+    pages := int((total + int64(max) - 1) / int64(max))
+    sitemaps := make([]sitemap.Sitemap, pages)
+    for i := range sitemaps {
+        page := types.Int64ToString(int64(i + 1))
+        sitemaps[i] = sitemap.Sitemap{Loc: route.URL(route.RouteName2URL("sitemap.page", "page", page))}
+    }
+    logger.LogError(sitemap.WriteIndex(w, sitemaps))
+}
+
+// Page 站点地图索引中的第 page 个站点地图
+func (sc *SitemapController) Page(w http.ResponseWriter, r *http.Request) {
+    total, err := sc.total()
+    if err != nil {
+        sc.ResponseForServerError(w, r, err)
+        return
+    }
+
+    max := sc.maxURLs()
+    page := types.StringToInt(route.GetRouterParam("page", r))
+    if page < 1 || int64(page-1)*int64(max) >= total {
+        view.RenderError(w, r, http.StatusNotFound, "")
+        return
+    }
+
+    urls, err := sc.urls((page-1)*max, max)
+    if err != nil {
+        sc.ResponseForServerError(w, r, err)
+        return
+    }
+    w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+    logger.LogError(sitemap.WriteURLSet(w, urls))
+}
+
+// Robots 输出 robots.txt，禁止抓取的路径按 app.env 读取 robots.disallow 配置，
+// 未配置的环境使用 robots.disallow.default，仍未配置时禁止抓取整个站点
+func (sc *SitemapController) Robots(w http.ResponseWriter, r *http.Request) {
+    var b strings.Builder
+    b.WriteString("User-agent: *\n")
+
+    disallow := config.GetString("robots.disallow."+config.GetString("app.env"),
+        config.GetString("robots.disallow.default", "/"))
+    paths := strings.Split(disallow, ",")
+    written := false
+    for _, path := range paths {
+        if path = strings.TrimSpace(path); path != "" {
+            b.WriteString("Disallow: " + path + "\n")
+            written = true
+        }
+    }
+    if !written {
+        // 空的 Disallow 表示允许抓取全部路径
+        b.WriteString("Disallow:\n")
+    }
+
+    b.WriteString("\nSitemap: " + route.URL(route.RouteName2URL("sitemap.index")) + "\n")
+
+    w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+    w.Write([]byte(b.String()))
+}
+
+// sources 站点地图的链接依次为：静态页面、用户页面、文章页面
+func (sc *SitemapController) sources() []sitemapSource {
+    names := cast.ToStringSlice(config.Get("sitemap.routes"))
+
+    return []sitemapSource{
+        {
+            count: func() (int64, error) {
+                return int64(len(names)), nil
+            },
+            urls: func(offset int, limit int) ([]sitemap.URL, error) {
+                var urls []sitemap.URL
+                for _, name := range names[offset : offset+limit] {
+                    urls = append(urls, sitemap.URL{Loc: route.URL(route.RouteName2URL(name))})
+                }
+                return urls, nil
+            },
+        },
+        {
+            count: user.Count,
+            urls: func(offset int, limit int) ([]sitemap.URL, error) {
+                users, err := user.GetForSitemap(offset, limit)
+                urls := make([]sitemap.URL, len(users))
+                for i, u := range users {
+                    urls[i] = sitemap.URL{Loc: route.URL(u.Link()), LastMod: u.UpdatedAt}
+                }
+                return urls, err
+            },
+        },
+        {
+            count: article.Count,
+            urls: func(offset int, limit int) ([]sitemap.URL, error) {
+                articles, err := article.GetForSitemap(offset, limit)
+                urls := make([]sitemap.URL, len(articles))
+                for i, a := range articles {
+                    urls[i] = sitemap.URL{Loc: route.URL(a.Link()), LastMod: a.UpdatedAt}
+                }
+                return urls, err
+            },
+        },
+    }
+}
+
+// total 全部链接数
+func (sc *SitemapController) total() (int64, error) {
+    var total int64
+    for _, source := range sc.sources() {
+        count, err := source.count()
+        if err != nil {
+            return 0, err
+        }
+        total += count
+    }
+    return total, nil
+}
+
+// urls 按顺序读取第 offset 个开始的 limit 个链接，可跨越多类链接
+func (sc *SitemapController) urls(offset int, limit int) ([]sitemap.URL, error) {
+    var urls []sitemap.URL
+    for _, source := range sc.sources() {
+        if limit <= 0 {
+            break
+        }
+        count, err := source.count()
+        if err != nil {
+            return nil, err
+        }
+        if int64(offset) >= count {
+            offset -= int(count)
+            continue
+        }
+
+        n := limit
+        if rest := int(count) - offset; n > rest {
+            n = rest
+        }
+        part, err := source.urls(offset, n)
+        if err != nil {
+            return nil, err
+        }
+        urls = append(urls, part...)
+        limit -= n
+        offset = 0
+    }
+    return urls, nil
+}
+
+// maxURLs 单个站点地图的最大链接数
+func (sc *SitemapController) maxURLs() int {
+    max := config.GetInt("sitemap.max_urls")
+    if max <= 0 || max > 50000 {
+        max = 50000
+    }
+    return max
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/tag"
+    "goblog/pkg/route"
+    "goblog/pkg/view"
+    "net/http"
+)
+
+// TagsController 文章标签控制器
+type TagsController struct {
+    BaseController
+}
+
+// Show 显示标签下的文章列表
+func (tc *TagsController) Show(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 获取 URL 参数
+    slug := route.GetRouterParam("slug", r)
+
+    // 2. 读取对应的标签
+    _tag, err := tag.GetBySlug(slug)
+
+    // 3. 如果出现错误
+    if err != nil {
+        tc.ResponseForSQLError(w, r, err)
+    } else {
+        // 4. 读取成功，显示标签下的文章
+        articles, pagerData, err := article.GetByTagID(r, _tag.GetStringID(), _tag.Slug, 0)
+        if err != nil {
+            tc.ResponseForServerError(w, r, err)
+        } else {
+            view.Render(w, r, view.D{
+                "Tag":       _tag,
+                "Articles":  articles,
+                "PagerData": pagerData,
+            }, "tags.show")
+        }
+    }
+}
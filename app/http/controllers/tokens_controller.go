@@ -0,0 +1,88 @@
+package controllers
+
+import (
+    "goblog/app/models/token"
+    "goblog/app/requests"
+    "goblog/pkg/auth"
+    "goblog/pkg/flash"
+    "goblog/pkg/route"
+    "goblog/pkg/view"
+    "net/http"
+    "strings"
+)
+
+// TokensController 个人访问令牌控制器
+type TokensController struct {
+    BaseController
+}
+
+// Index 令牌列表及创建表单
+func (tc *TokensController) Index(w http.ResponseWriter, r *http.Request) {
+    tc.render(w, r, view.D{
+        "Token":  token.PersonalAccessToken{Scopes: token.ScopeRead},
+        "Errors": view.D{},
+    })
+}
+
+// Store 创建令牌，明文令牌只在本次响应中显示
+func (tc *TokensController) Store(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 初始化数据
+    r.ParseForm()
+    _token := token.PersonalAccessToken{
+        UserID: auth.User(r).ID,
+        Name:   strings.TrimSpace(r.PostFormValue("name")),
+        Scopes: strings.Join(r.PostForm["scopes"], ","),
+    }
+
+    // 2. 表单验证
+    errors := requests.ValidateTokenForm(_token)
+    if len(errors) > 0 {
+        tc.render(w, r, view.D{
+            "Token":  _token,
+            "Errors": errors,
+        })
+        return
+    }
+
+    // 3. 创建令牌
+    plainText, err := _token.Create()
+    if err != nil {
+        tc.ResponseForServerError(w, r, err)
+        return
+    }
+    tc.render(w, r, view.D{
+        "Token":      token.PersonalAccessToken{Scopes: token.ScopeRead},
+        "Errors":     view.D{},
+        "PlainToken": plainText,
+        "NewToken":   _token,
+    })
+}
+
+// Delete 撤销令牌
+func (tc *TokensController) Delete(w http.ResponseWriter, r *http.Request) {
+    _token, err := token.GetForUser(route.GetRouterParam("id", r), auth.User(r).ID)
+    if err != nil {
+        tc.ResponseForSQLError(w, r, err)
+        return
+    }
+
+    if _, err := _token.Delete(); err != nil {
+        tc.ResponseForServerError(w, r, err)
+        return
+    }
+    flash.Success(r, "令牌「"+_token.Name+"」已撤销")
+    http.Redirect(w, r, route.RouteName2URL("settings.tokens"), http.StatusFound)
+}
+
+// render 渲染令牌页面，附带当前用户的令牌列表
+func (tc *TokensController) render(w http.ResponseWriter, r *http.Request, data view.D) {
+    tokens, err := token.GetByUserID(auth.User(r).ID)
+    if err != nil {
+        tc.ResponseForServerError(w, r, err)
+        return
+    }
+    data["Tokens"] = tokens
+    data["Scopes"] = token.Scopes
+    view.Render(w, r, data, "settings.tokens")
+}
@@ -1,14 +1,11 @@
 package controllers
 
 import (
-    "fmt"
     "goblog/app/models/article"
     "goblog/app/models/user"
-    "goblog/pkg/logger"
     "goblog/pkg/route"
     "goblog/pkg/view"
     "net/http"
-
 )
 
 // UserController 用户控制器
@@ -27,17 +24,20 @@ func (uc *UserController) Show(w http.ResponseWriter, r *http.Request) {
 
     // 3. 如果出现错误
     if err != nil {
-        uc.ResponseForSQLError(w, err)
+        uc.ResponseForSQLError(w, r, err)
     } else {
         // ---  4. 读取成功，显示用户文章列表 ---
-        articles, err := article.GetByUserID(_user.GetStringID())
+        articles, pagerData, err := article.GetByUserID(r, _user.GetStringID(), 0)
         if err != nil {
-            logger.LogError(err)
-            w.WriteHeader(http.StatusInternalServerError)
-            fmt.Fprint(w, "500 服务器内部错误")
+            uc.ResponseForServerError(w, r, err)
         } else {
-            view.Render(w, view.D{
-                "Articles": articles,
+            view.Render(w, r, view.D{
+                "Articles":   articles,
+                "PagerData":  pagerData,
+                "AuthorFeed": view.D{
+                    "Title": _user.Name + " 的文章",
+                    "URL":   route.RouteName2URL("users.feed", "id", _user.GetStringID()),
+                },
             }, "articles.index", "articles._article_meta")
         }
     }
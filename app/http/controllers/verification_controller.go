@@ -0,0 +1,103 @@
+package controllers
+
+import (
+    "fmt"
+    "goblog/app/models/user"
+    "goblog/pkg/auth"
+    "goblog/pkg/config"
+    "goblog/pkg/flash"
+    "goblog/pkg/logger"
+    "goblog/pkg/mail"
+    "goblog/pkg/route"
+    "goblog/pkg/signedurl"
+    "goblog/pkg/view"
+    "html/template"
+    "net/http"
+    "time"
+)
+
+// VerificationController 邮箱验证控制器
+type VerificationController struct {
+    BaseController
+}
+
+// Notice 提示用户查收验证邮件，已验证时跳转到首页
+func (*VerificationController) Notice(w http.ResponseWriter, r *http.Request) {
+    _user := auth.User(r)
+    if _user.IsVerified() {
+        http.Redirect(w, r, "/", http.StatusFound)
+        return
+    }
+    view.RenderSimple(w, r, view.D{
+        "User": _user,
+    }, "auth.verify")
+}
+
+// Verify 处理验证链接，链接带有签名，无需登录
+func (vc *VerificationController) Verify(w http.ResponseWriter, r *http.Request) {
+
+    // 1. 校验签名和邮箱摘要
+    if !signedurl.Verify(r) {
+        view.RenderError(w, r, http.StatusForbidden, "验证链接无效或已过期，请重新发送验证邮件")
+        return
+    }
+    _user, err := user.Get(route.GetRouterParam("id", r))
+    if err != nil {
+        vc.ResponseForSQLError(w, r, err)
+        return
+    }
+    if route.GetRouterParam("hash", r) != _user.EmailHash() {
+        view.RenderError(w, r, http.StatusForbidden, "验证链接无效或已过期，请重新发送验证邮件")
+        return
+    }
+
+    // 2. 标记为已验证
+    if _user.IsVerified() {
+        flash.Info(r, "您的邮箱已经验证过了")
+    } else {
+        if err := _user.MarkEmailAsVerified(); err != nil {
+            vc.ResponseForServerError(w, r, err)
+            return
+        }
+        flash.Success(r, "邮箱验证成功！")
+    }
+    http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// Resend 重新发送验证邮件
+func (*VerificationController) Resend(w http.ResponseWriter, r *http.Request) {
+    _user := auth.User(r)
+    if _user.IsVerified() {
+        http.Redirect(w, r, "/", http.StatusFound)
+        return
+    }
+
+    if err := sendVerificationEmail(_user); err != nil {
+        logger.LogError(err)
+        flash.Danger(r, "邮件发送失败，请稍后再试")
+    } else {
+        flash.Success(r, "验证邮件已重新发送到 "+_user.Email)
+    }
+    http.Redirect(w, r, route.RouteName2URL("verification.notice"), http.StatusFound)
+}
+
+// sendVerificationEmail 发送带签名验证链接的邮件
+func sendVerificationEmail(_user user.User) error {
+    appName := config.GetString("app.name")
+    expire := config.GetInt("auth.verification_expire", 1440)
+
+    path := route.RouteName2URL("verification.verify", "id", _user.GetStringID(), "hash", _user.EmailHash())
+    link := route.URL(signedurl.Sign(path, time.Now().Add(time.Duration(expire)*time.Minute)))
+
+    text := fmt.Sprintf("%s，您好：\n\n感谢您注册 %s，请访问以下链接验证您的邮箱：\n\n%s\n\n链接将在 %d 分钟后失效。\n",
+        _user.Name, appName, link, expire)
+    html := fmt.Sprintf(`<p>%s，您好：</p><p>感谢您注册 %s，请点击以下链接验证您的邮箱：</p><p><a href="%s">验证邮箱</a></p><p>链接将在 %d 分钟后失效。</p>`,
+        template.HTMLEscapeString(_user.Name), template.HTMLEscapeString(appName), template.HTMLEscapeString(link), expire)
+
+    return mail.Send(mail.Message{
+        To:      []mail.Address{{Address: _user.Email, Name: _user.Name}},
+        Subject: "验证邮箱 - " + appName,
+        Text:    text,
+        HTML:    html,
+    })
+}
@@ -10,8 +10,8 @@ import (
 func Auth(next HttpHandlerFunc) HttpHandlerFunc {
     return func(w http.ResponseWriter, r *http.Request) {
 
-        if !auth.Check() {
-            flash.Warning("登录用户才能访问此页面")
+        if !auth.Check(r) {
+            flash.Warning(r, "登录用户才能访问此页面")
             http.Redirect(w, r, "/", http.StatusFound)
             return
         }
@@ -0,0 +1,20 @@
+package middwares
+
+import (
+    "goblog/pkg/auth"
+    "goblog/pkg/response"
+    "net/http"
+)
+
+// AuthAPI 登录用户才可访问的接口，未登录时返回 401
+func AuthAPI(next HttpHandlerFunc) HttpHandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+
+        if !auth.Check(r) {
+            response.Error(w, http.StatusUnauthorized, "请先登录")
+            return
+        }
+
+        next(w, r)
+    }
+}
@@ -0,0 +1,55 @@
+package middwares
+
+import (
+    "goblog/app/models/token"
+    "goblog/pkg/auth"
+    "goblog/pkg/response"
+    "net/http"
+    "strings"
+)
+
+// AuthenticateToken 通过 Authorization: Bearer 标头中的个人访问令牌认证用户，
+// 未携带令牌的请求继续使用会话认证
+func AuthenticateToken(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+        plainText, ok := bearerToken(r)
+        if !ok {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        // 1. 令牌不存在或已撤销
+        _token, err := token.FindByPlainText(plainText)
+        if err != nil {
+            response.Error(w, http.StatusUnauthorized, "访问令牌无效或已被撤销")
+            return
+        }
+
+        // 2. 令牌的权限范围需覆盖本次请求
+        if scope := token.ScopeForMethod(r.Method); !_token.Can(scope) {
+            response.Error(w, http.StatusForbidden, "访问令牌缺少 "+scope+" 权限")
+            return
+        }
+
+        // 3. 以令牌所属用户的身份继续处理请求
+        _token.Touch()
+        next.ServeHTTP(w, auth.WithUser(r, _token.User))
+    })
+}
+
+// isBearerRequest 请求是否携带了 Bearer 令牌
+func isBearerRequest(r *http.Request) bool {
+    _, ok := bearerToken(r)
+    return ok
+}
+
+// bearerToken 读取 Authorization 标头中的令牌
+func bearerToken(r *http.Request) (string, bool) {
+    header := r.Header.Get("Authorization")
+    if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+        return "", false
+    }
+    plainText := strings.TrimSpace(header[7:])
+    return plainText, plainText != ""
+}
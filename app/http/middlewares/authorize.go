@@ -0,0 +1,22 @@
+package middwares
+
+import (
+    "goblog/pkg/view"
+    "goblog/policies"
+    "net/http"
+)
+
+// Authorize 具备 ability 能力的用户才可访问，否则显示 403 页面，需在 Auth 之后使用
+func Authorize(ability string) func(HttpHandlerFunc) HttpHandlerFunc {
+    return func(next HttpHandlerFunc) HttpHandlerFunc {
+        return func(w http.ResponseWriter, r *http.Request) {
+
+            if !policies.Allows(r, ability, nil) {
+                view.RenderError(w, r, http.StatusForbidden, "")
+                return
+            }
+
+            next(w, r)
+        }
+    }
+}
@@ -0,0 +1,13 @@
+package middwares
+
+import (
+    "goblog/policies"
+    "net/http"
+)
+
+// CacheGrants 在请求内缓存登录用户的角色和权限，页面中多次授权检查只查询一次
+func CacheGrants(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        next.ServeHTTP(w, policies.WithCache(r))
+    })
+}
@@ -0,0 +1,35 @@
+package middwares
+
+import (
+    "fmt"
+    "goblog/pkg/logger"
+    "goblog/pkg/view"
+    "net/http"
+
+    "go.uber.org/zap"
+)
+
+// Recover 捕获处理请求时发生的 panic，记录堆栈信息并显示 500 页面
+func Recover(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        defer func() {
+            if err := recover(); err != nil {
+                // http.ErrAbortHandler 用于主动中断请求，交由 net/http 处理
+                if err == http.ErrAbortHandler {
+                    panic(err)
+                }
+
+                logger.WithContext(r.Context()).Error("Recovery from panic",
+                    zap.String("error", fmt.Sprint(err)),
+                    zap.String("method", r.Method),
+                    zap.String("path", r.URL.Path),
+                    zap.Stack("stacktrace"),
+                )
+
+                view.RenderError(w, r, http.StatusInternalServerError, "")
+            }
+        }()
+
+        next.ServeHTTP(w, r)
+    })
+}
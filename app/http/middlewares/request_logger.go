@@ -0,0 +1,66 @@
+package middwares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"goblog/pkg/logger"
+	"goblog/pkg/realip"
+	"goblog/pkg/session"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// responseRecorder 记录响应的状态码
+type responseRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+    rec.status = code
+    rec.ResponseWriter.WriteHeader(code)
+}
+
+// RequestLogger 为请求分配 request_id，将 request_id 和 user_id 附加到日志上下文，并记录访问日志
+// 需在 StartSession 之后注册，才能读取到会话中的用户 ID
+func RequestLogger(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+
+        // 1. 优先使用上游（如 Nginx）传入的请求 ID
+        requestID := r.Header.Get("X-Request-ID")
+        if len(requestID) == 0 {
+            requestID = newRequestID()
+        }
+        w.Header().Set("X-Request-ID", requestID)
+
+        // 2. 附加上下文字段，后续通过 logger.WithContext(r.Context()) 记录的日志都会带上
+        fields := []zap.Field{zap.String("request_id", requestID)}
+        if uid, ok := session.Get(r, "uid").(string); ok && len(uid) > 0 {
+            fields = append(fields, zap.String("user_id", uid))
+        }
+        r = r.WithContext(logger.NewContext(r.Context(), fields...))
+
+        // 3. 继续处理请求
+        rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+        next.ServeHTTP(rec, r)
+
+        // 4. 记录访问日志
+        logger.WithContext(r.Context()).Info("HTTP Access",
+            zap.String("method", r.Method),
+            zap.String("path", r.URL.Path),
+            zap.Int("status", rec.status),
+            zap.Duration("duration", time.Since(start)),
+            zap.String("ip", realip.FromRequest(r)),
+        )
+    })
+}
+
+// newRequestID 生成 16 个字符的随机请求 ID
+func newRequestID() string {
+    b := make([]byte, 8)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
@@ -9,10 +9,10 @@ import (
 func StartSession(next http.Handler) http.Handler {
     return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-        // 1. 启动会话
-        session.StartSession(w, r)
+        // 1. 启动会话，会话保存在请求的 Context 中，各请求之间互不干扰
+        r = session.StartSession(w, r)
 
-        // 2. . 继续处理接下去的请求
+        // 2. 继续处理接下去的请求
         next.ServeHTTP(w, r)
     })
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package middwares
+
+import (
+    "goblog/pkg/auth"
+    "goblog/pkg/flash"
+    "goblog/pkg/response"
+    "goblog/pkg/route"
+    "net/http"
+)
+
+// Verified 邮箱已验证的用户才可访问，需在 Auth 之后使用
+func Verified(next HttpHandlerFunc) HttpHandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+
+        if !auth.User(r).IsVerified() {
+            if response.WantsJSON(r) {
+                response.Error(w, http.StatusForbidden, "请先验证您的邮箱")
+                return
+            }
+            flash.Warning(r, "请先验证您的邮箱")
+            http.Redirect(w, r, route.RouteName2URL("verification.notice"), http.StatusFound)
+            return
+        }
+
+        next(w, r)
+    }
+}
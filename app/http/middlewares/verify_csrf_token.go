@@ -0,0 +1,26 @@
+package middwares
+
+import (
+    "goblog/pkg/auth"
+    "goblog/pkg/csrf"
+    "goblog/pkg/view"
+    "net/http"
+    "strings"
+)
+
+// VerifyCSRFToken 校验 GET、HEAD、OPTIONS 以外请求的 CSRF 令牌，校验失败时显示 419 页面。
+// 携带 Bearer 令牌或未通过会话登录的 API 请求不依赖 Cookie 认证，无需校验
+func VerifyCSRFToken(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+        switch {
+        case r.Method == http.MethodGet, r.Method == http.MethodHead, r.Method == http.MethodOptions:
+        case strings.HasPrefix(r.URL.Path, "/api/") && (isBearerRequest(r) || !auth.Check(r)):
+        case !csrf.Verify(r):
+            view.RenderError(w, r, 419, "")
+            return
+        }
+
+        next.ServeHTTP(w, r)
+    })
+}
@@ -2,10 +2,20 @@ package article
 
 import (
 	"goblog/app/models"
+	"goblog/app/models/category"
+	"goblog/app/models/comment"
+	"goblog/app/models/tag"
 	"goblog/app/models/user"
 	"goblog/pkg/logger"
+	"goblog/pkg/markdown"
 	"goblog/pkg/model"
 	"goblog/pkg/route"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 
@@ -17,6 +27,15 @@ type Article struct {
 	
 	UserID uint64 `gorm:"not null;index"`
     User   user.User
+
+    // CategoryID 为 0 表示未分类
+    CategoryID uint64 `gorm:"not null;default:0;index" valid:"category_id"`
+    Category   category.Category
+
+    Tags []tag.Tag `gorm:"many2many:article_tags"`
+
+    // TagNames 表单中以逗号分隔的标签，保存文章时据此同步 Tags
+    TagNames string `gorm:"-" valid:"tags"`
 }
 
 // Link 方法用来生成文章链接
@@ -24,28 +43,98 @@ func (a Article) Link() string {
     return route.RouteName2URL("articles.show", "id", a.GetStringID())
 }
 
-func (article *Article) Update() (rowsAffected int64, err error){
-	result := model.DB.Save(&article)
-
-	if err := result.Error; err != nil {
-		logger.LogError(err)
-		return 0, err
-	}
-	return result.RowsAffected, nil
+// Update 更新文章，文章与标签的同步在同一事务中完成
+func (article *Article) Update() (rowsAffected int64, err error) {
+    err = model.DB.Transaction(func(tx *gorm.DB) error {
+        // 不保存关联，否则预加载的 Category 会覆盖修改后的 CategoryID
+        result := tx.Omit(clause.Associations).Save(&article)
+        if err := result.Error; err != nil {
+            return err
+        }
+        rowsAffected = result.RowsAffected
+        return article.syncTags(tx)
+    })
+    if err != nil {
+        logger.LogError(err)
+        return 0, err
+    }
+    return rowsAffected, nil
 }
 
-// Delete 删除文章
+// Delete 删除文章，评论、article_tags 中的关联记录与文章在同一事务中删除
 func (article *Article) Delete() (rowsAffected int64, err error) {
-    result := model.DB.Delete(&article)
-    if err = result.Error; err != nil {
+    err = model.DB.Transaction(func(tx *gorm.DB) error {
+        if err := comment.DeleteByArticleID(tx, article.ID); err != nil {
+            return err
+        }
+        result := tx.Select("Tags").Delete(&article)
+        if err := result.Error; err != nil {
+            return err
+        }
+        rowsAffected = result.RowsAffected
+        return nil
+    })
+    if err != nil {
         logger.LogError(err)
         return 0, err
     }
+    markdown.Forget(article.markdownCacheKey())
 
-    return result.RowsAffected, nil
+    return rowsAffected, nil
 }
 
 // CreatedAtDate 创建日期
 func (a Article) CreatedAtDate() string {
     return a.CreatedAt.Format("2006-01-02")
-}
\ No newline at end of file
+}
+
+// HTML 将 Markdown 格式的正文渲染为 HTML，按文章的更新时间缓存，模板中使用 {{ .HTML }}
+func (a Article) HTML() template.HTML {
+    // 未保存的文章没有版本信息，不做缓存
+    if a.ID == 0 {
+        return markdown.Render(a.Body)
+    }
+    revision := strconv.FormatInt(a.UpdatedAt.UnixNano(), 10)
+    return markdown.RenderCached(a.markdownCacheKey(), revision, a.Body)
+}
+
+// PlainBody 去掉 Markdown 格式的正文，用于搜索索引和摘要
+func (a Article) PlainBody() string {
+    return markdown.PlainText(a.Body)
+}
+
+// Excerpt 正文摘要，截取纯文本的前 length 个字
+func (a Article) Excerpt(length int) string {
+    runes := []rune(a.PlainBody())
+    if len(runes) <= length {
+        return string(runes)
+    }
+    return string(runes[:length]) + "…"
+}
+
+func (a Article) markdownCacheKey() string {
+    return "article:" + a.GetStringID()
+}
+
+
+// TagList 以逗号分隔的标签名称，用于编辑表单回显
+func (a Article) TagList() string {
+    names := make([]string, len(a.Tags))
+    for i, t := range a.Tags {
+        names[i] = t.Name
+    }
+    return strings.Join(names, ", ")
+}
+
+// syncTags 按 TagNames 同步文章的标签，不存在的标签自动创建
+func (article *Article) syncTags(tx *gorm.DB) error {
+    tags, err := tag.FirstOrCreateByNames(tx, tag.ParseNames(article.TagNames))
+    if err != nil {
+        return err
+    }
+    if err = tx.Model(article).Association("Tags").Replace(tags); err != nil {
+        return err
+    }
+    article.Tags = tags
+    return nil
+}
@@ -3,7 +3,12 @@ package article
 import (
 	"goblog/pkg/logger"
 	"goblog/pkg/model"
+	"goblog/pkg/pagination"
 	"goblog/pkg/types"
+	"net/http"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 
@@ -13,37 +18,118 @@ import (
 func Get(idstr string) (Article, error) {
     var article Article
     id := types.StringToInt(idstr)
-    if err := model.DB.Preload("User").First(&article, id).Error; err != nil {
+    if err := model.DB.Preload("User").Preload("Category").Preload("Tags").First(&article, id).Error; err != nil {
         return article, err
     }
 
     return article, nil
 }
 
-// GetAll 获取全部文章
-func GetAll() ([]Article, error) {
-    var articles []Article
-    if err := model.DB.Debug().Preload("User").Find(&articles).Error; err != nil {
-        return articles, err
+// GetAll 获取全部文章，按发布时间倒序分页
+func GetAll(r *http.Request, perPage int) ([]Article, pagination.ViewData, error) {
+    return Paginate(r, perPage, "home")
+}
+
+// Paginate 与 GetAll 相同，分页链接通过 routeName 和 pars 生成，用于首页以外的入口，如 API
+func Paginate(r *http.Request, perPage int, routeName string, pars ...string) ([]Article, pagination.ViewData, error) {
+    return paginate(r, model.DB.Model(Article{}), perPage, routeName, pars...)
+}
+
+// GetByIDs 按 ids 的顺序获取文章，不存在的文章被忽略，用于搜索结果
+func GetByIDs(ids []uint64) ([]Article, error) {
+    var found []Article
+    if len(ids) == 0 {
+        return found, nil
+    }
+    if err := model.DB.Where("id IN ?", ids).Preload("User").Preload("Category").Preload("Tags").Find(&found).Error; err != nil {
+        return found, err
+    }
+
+    byID := make(map[uint64]Article, len(found))
+    for _, a := range found {
+        byID[a.ID] = a
+    }
+    articles := make([]Article, 0, len(found))
+    for _, id := range ids {
+        if a, ok := byID[id]; ok {
+            articles = append(articles, a)
+        }
     }
     return articles, nil
 }
 
 // Create 创建文章，通过 article.ID 来判断是否创建成功
 func (article *Article) Create() (err error) {
-    if err = model.DB.Create(&article).Error; err != nil {
+    err = model.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Omit(clause.Associations).Create(&article).Error; err != nil {
+            return err
+        }
+        return article.syncTags(tx)
+    })
+    if err != nil {
         logger.LogError(err)
-        return err
+        // 事务回滚后 ID 无效，调用方据此判断创建失败
+        article.ID = 0
     }
-
-    return nil
+    return err
 }
 
-// GetByUserID 获取全部文章
-func GetByUserID(uid string) ([]Article, error) {
+// GetRecent 获取最新发布的 limit 篇文章，uid 不为空时只获取该用户的文章，用于订阅源
+func GetRecent(uid string, limit int) ([]Article, error) {
     var articles []Article
-    if err := model.DB.Where("user_id = ?", uid).Preload("User").Find(&articles).Error; err != nil {
+    query := model.DB.Preload("User").Order("created_at DESC, id DESC").Limit(limit)
+    if uid != "" {
+        query = query.Where("user_id = ?", uid)
+    }
+    if err := query.Find(&articles).Error; err != nil {
         return articles, err
     }
     return articles, nil
-}
\ No newline at end of file
+}
+
+// Count 文章总数
+func Count() (int64, error) {
+    var count int64
+    err := model.DB.Model(&Article{}).Count(&count).Error
+    return count, err
+}
+
+// GetForSitemap 按 ID 顺序分段获取文章，只读取生成链接需要的字段，用于站点地图
+func GetForSitemap(offset int, limit int) ([]Article, error) {
+    var articles []Article
+    err := model.DB.Select("id", "updated_at").Order("id").Offset(offset).Limit(limit).Find(&articles).Error
+    return articles, err
+}
+
+// GetByUserID 获取用户的全部文章，按发布时间倒序分页
+func GetByUserID(r *http.Request, uid string, perPage int) ([]Article, pagination.ViewData, error) {
+    query := model.DB.Model(Article{}).Where("user_id = ?", uid)
+    return paginate(r, query, perPage, "users.show", "id", uid)
+}
+
+// GetByCategoryID 获取分类下的全部文章，按发布时间倒序分页
+func GetByCategoryID(r *http.Request, cid string, perPage int) ([]Article, pagination.ViewData, error) {
+    query := model.DB.Model(Article{}).Where("category_id = ?", cid)
+    return paginate(r, query, perPage, "categories.show", "id", cid)
+}
+
+// GetByTagID 获取标签下的全部文章，按发布时间倒序分页，slug 用以生成分页链接
+func GetByTagID(r *http.Request, tid string, slug string, perPage int) ([]Article, pagination.ViewData, error) {
+    query := model.DB.Model(Article{}).
+        Joins("JOIN article_tags ON article_tags.article_id = articles.id").
+        Where("article_tags.tag_id = ?", tid)
+    return paginate(r, query, perPage, "tags.show", "slug", slug)
+}
+
+// paginate 分页读取文章及其作者、分类和标签，routeName 和 pars 用以生成分页链接
+func paginate(r *http.Request, query *gorm.DB, perPage int, routeName string, pars ...string) ([]Article, pagination.ViewData, error) {
+    var articles []Article
+
+    query = query.Preload("User").Preload("Category").Preload("Tags").
+        Order("articles.created_at DESC, articles.id DESC")
+    _pager := pagination.New(r, query, perPage, routeName, pars...)
+    viewData := _pager.Paging()
+
+    err := _pager.Results(&articles)
+    return articles, viewData, err
+}
@@ -0,0 +1,37 @@
+package article
+
+import (
+	"goblog/pkg/logger"
+	"goblog/pkg/search"
+
+	"gorm.io/gorm"
+)
+
+// AfterCreate 创建文章后加入搜索索引，索引失败不影响保存
+func (article *Article) AfterCreate(tx *gorm.DB) error {
+    logger.LogError(search.Index(article.SearchDocument()))
+    return nil
+}
+
+// AfterUpdate 更新文章后更新搜索索引
+func (article *Article) AfterUpdate(tx *gorm.DB) error {
+    logger.LogError(search.Index(article.SearchDocument()))
+    return nil
+}
+
+// AfterDelete 删除文章后从搜索索引中删除
+func (article *Article) AfterDelete(tx *gorm.DB) error {
+    logger.LogError(search.Remove(article.ID))
+    return nil
+}
+
+// SearchDocument 用于搜索索引的文章内容，正文去掉 Markdown 格式
+func (a Article) SearchDocument() search.Document {
+    return search.Document{
+        ID:        a.ID,
+        UserID:    a.UserID,
+        Title:     a.Title,
+        Body:      a.PlainBody(),
+        CreatedAt: a.CreatedAt,
+    }
+}
@@ -0,0 +1,22 @@
+package category
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/route"
+)
+
+// Category 文章分类
+type Category struct {
+    models.BaseModel
+
+    Name        string `gorm:"type:varchar(191);not null;unique" valid:"name"`
+    Description string `gorm:"type:varchar(255)" valid:"description"`
+
+    // ArticlesCount 分类下的文章数，只读字段，由 AllWithArticlesCount 查询填充
+    ArticlesCount int64 `gorm:"->"`
+}
+
+// Link 方法用来生成分类链接
+func (c Category) Link() string {
+    return route.RouteName2URL("categories.show", "id", c.GetStringID())
+}
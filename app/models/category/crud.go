@@ -0,0 +1,49 @@
+package category
+
+import (
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+	"goblog/pkg/types"
+)
+
+// Create 创建分类，通过 category.ID 来判断是否创建成功
+func (category *Category) Create() (err error) {
+    if err = model.DB.Create(&category).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+
+    return nil
+}
+
+// Get 通过 ID 获取分类
+func Get(idstr string) (Category, error) {
+    var category Category
+    id := types.StringToInt(idstr)
+    if err := model.DB.First(&category, id).Error; err != nil {
+        return category, err
+    }
+
+    return category, nil
+}
+
+// All 获取所有分类，用于文章表单的分类选择
+func All() ([]Category, error) {
+    var categories []Category
+    if err := model.DB.Order("id").Find(&categories).Error; err != nil {
+        return categories, err
+    }
+    return categories, nil
+}
+
+// AllWithArticlesCount 获取所有分类及各分类下的文章数，用于侧边栏
+func AllWithArticlesCount() ([]Category, error) {
+    var categories []Category
+    err := model.DB.Model(&Category{}).
+        Select("categories.*, COUNT(articles.id) AS articles_count").
+        Joins("LEFT JOIN articles ON articles.category_id = categories.id").
+        Group("categories.id").
+        Order("categories.id").
+        Find(&categories).Error
+    return categories, err
+}
@@ -0,0 +1,85 @@
+package comment
+
+import (
+	"goblog/app/models"
+	"goblog/app/models/user"
+)
+
+// 评论的审核状态
+const (
+    StatusPending  = "pending"
+    StatusApproved = "approved"
+    StatusSpam     = "spam"
+)
+
+// Comment 文章评论，ParentID 不为 0 时为对其他评论的回复
+type Comment struct {
+    models.BaseModel
+
+    ArticleID uint64 `gorm:"not null;index"`
+    UserID    uint64 `gorm:"not null;index"`
+    User      user.User
+
+    ParentID uint64 `gorm:"not null;default:0;index" valid:"parent_id"`
+    Body     string `gorm:"type:text;not null" valid:"body"`
+    Status   string `gorm:"type:varchar(20);not null;default:pending;index"`
+
+    // Replies 回复列表，由 Tree 组装
+    Replies []*Comment `gorm:"-"`
+}
+
+// IsPending 是否待审核
+func (c Comment) IsPending() bool {
+    return c.Status == StatusPending
+}
+
+// IsSpam 是否被标记为垃圾评论
+func (c Comment) IsSpam() bool {
+    return c.Status == StatusSpam
+}
+
+// CreatedAtDate 评论时间
+func (c Comment) CreatedAtDate() string {
+    return c.CreatedAt.Format("2006-01-02 15:04")
+}
+
+// Thread 文章的评论列表，模板中递归渲染回复时使用
+type Thread struct {
+    Comments []*Comment
+
+    // ArticleID 用以生成评论和回复的表单地址
+    ArticleID string
+
+    // CanReply 当前用户是否可以发表回复
+    CanReply bool
+
+    // CanModerate 当前用户是否可以审核和删除评论
+    CanModerate bool
+}
+
+// With 使用同样的权限设置渲染另一组评论，如 {{ template "comment-thread" ($thread.With .Replies) }}
+func (t Thread) With(comments []*Comment) Thread {
+    t.Comments = comments
+    return t
+}
+
+// Tree 将评论组装为树形结构，返回顶层评论。父评论不在列表中（如未通过审核）的回复不显示
+func Tree(comments []Comment) []*Comment {
+    nodes := make(map[uint64]*Comment, len(comments))
+    for i := range comments {
+        c := comments[i]
+        c.Replies = nil
+        nodes[c.ID] = &c
+    }
+
+    var roots []*Comment
+    for i := range comments {
+        node := nodes[comments[i].ID]
+        if node.ParentID == 0 {
+            roots = append(roots, node)
+        } else if parent, ok := nodes[node.ParentID]; ok {
+            parent.Replies = append(parent.Replies, node)
+        }
+    }
+    return roots
+}
@@ -0,0 +1,77 @@
+package comment
+
+import (
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+	"goblog/pkg/types"
+
+	"gorm.io/gorm"
+)
+
+// Create 创建评论，通过 comment.ID 来判断是否创建成功
+func (comment *Comment) Create() (err error) {
+    if err = model.DB.Create(&comment).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+
+    return nil
+}
+
+// Get 通过 ID 获取评论
+func Get(idstr string) (Comment, error) {
+    var comment Comment
+    id := types.StringToInt(idstr)
+    if err := model.DB.First(&comment, id).Error; err != nil {
+        return comment, err
+    }
+
+    return comment, nil
+}
+
+// ForArticle 获取文章的评论，按发布时间排序。
+// moderator 为 true 时返回全部评论，否则只返回已通过的评论和 viewerID 自己发表的待审核评论
+func ForArticle(articleID uint64, viewerID uint64, moderator bool) ([]Comment, error) {
+    var comments []Comment
+    query := model.DB.Where("article_id = ?", articleID)
+    if !moderator {
+        query = query.Where("status = ? OR (status = ? AND user_id = ?)", StatusApproved, StatusPending, viewerID)
+    }
+    err := query.Preload("User").Order("created_at, id").Find(&comments).Error
+    return comments, err
+}
+
+// SetStatus 修改审核状态
+func (comment *Comment) SetStatus(status string) error {
+    if err := model.DB.Model(comment).Update("status", status).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+    return nil
+}
+
+// Delete 删除评论及其下的所有回复
+func (comment *Comment) Delete() (rowsAffected int64, err error) {
+    ids := []uint64{comment.ID}
+    for parents := ids; len(parents) > 0; {
+        var children []uint64
+        if err = model.DB.Model(&Comment{}).Where("parent_id IN ?", parents).Pluck("id", &children).Error; err != nil {
+            logger.LogError(err)
+            return 0, err
+        }
+        ids = append(ids, children...)
+        parents = children
+    }
+
+    result := model.DB.Where("id IN ?", ids).Delete(&Comment{})
+    if err = result.Error; err != nil {
+        logger.LogError(err)
+        return 0, err
+    }
+    return result.RowsAffected, nil
+}
+
+// DeleteByArticleID 删除文章的全部评论，在删除文章的事务 tx 中调用
+func DeleteByArticleID(tx *gorm.DB, articleID uint64) error {
+    return tx.Where("article_id = ?", articleID).Delete(&Comment{}).Error
+}
@@ -0,0 +1,52 @@
+package passwordreset
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+	"time"
+)
+
+// Create 为用户生成新的重置令牌，并作废此前的令牌，返回用于重置链接的明文令牌
+func Create(uid uint64) (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        logger.LogError(err)
+        return "", err
+    }
+    plainText := hex.EncodeToString(b)
+
+    if err := DeleteByUserID(uid); err != nil {
+        return "", err
+    }
+
+    expire := time.Duration(config.GetInt("auth.password_reset_expire", 60)) * time.Minute
+    reset := PasswordReset{
+        UserID:    uid,
+        TokenHash: hash(plainText),
+        ExpiresAt: time.Now().Add(expire),
+    }
+    if err := model.DB.Create(&reset).Error; err != nil {
+        logger.LogError(err)
+        return "", err
+    }
+    return plainText, nil
+}
+
+// FindValid 根据明文令牌查找未过期的重置记录
+func FindValid(plainText string) (PasswordReset, error) {
+    var reset PasswordReset
+    err := model.DB.Where("token_hash = ? AND expires_at > ?", hash(plainText), time.Now()).First(&reset).Error
+    return reset, err
+}
+
+// DeleteByUserID 删除用户的全部重置令牌，在重置成功后调用
+func DeleteByUserID(uid uint64) error {
+    if err := model.DB.Where("user_id = ?", uid).Delete(&PasswordReset{}).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+    return nil
+}
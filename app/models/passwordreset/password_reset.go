@@ -0,0 +1,23 @@
+// Package passwordreset 密码重置令牌，只保存令牌的摘要，使用一次后即删除
+package passwordreset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"goblog/app/models"
+	"time"
+)
+
+// PasswordReset 密码重置令牌
+type PasswordReset struct {
+    models.BaseModel
+
+    UserID    uint64    `gorm:"not null;index"`
+    TokenHash string    `gorm:"type:char(64);not null;unique"`
+    ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func hash(plainText string) string {
+    sum := sha256.Sum256([]byte(plainText))
+    return hex.EncodeToString(sum[:])
+}
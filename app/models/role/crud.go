@@ -0,0 +1,128 @@
+package role
+
+import (
+	"fmt"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// All 获取全部角色及其权限，按 ID 排序
+func All() ([]Role, error) {
+    var roles []Role
+    err := model.DB.Preload("Permissions").Order("id").Find(&roles).Error
+    return roles, err
+}
+
+// GetByName 通过名称获取角色
+func GetByName(name string) (Role, error) {
+    var _role Role
+    if err := model.DB.Where("name = ?", name).First(&_role).Error; err != nil {
+        return _role, err
+    }
+
+    return _role, nil
+}
+
+// NamesOf 获取用户的角色名称
+func NamesOf(uid uint64) ([]string, error) {
+    names := []string{}
+    err := model.DB.Model(&Role{}).
+        Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+        Where("user_roles.user_id = ?", uid).
+        Order("roles.id").
+        Pluck("roles.name", &names).Error
+    return names, err
+}
+
+// NamesOfUsers 批量获取多个用户的角色名称，用于列表页
+func NamesOfUsers(uids []uint64) (map[uint64][]string, error) {
+    var rows []struct {
+        UserID uint64
+        Name   string
+    }
+    err := model.DB.Table("user_roles").
+        Select("user_roles.user_id, roles.name").
+        Joins("JOIN roles ON roles.id = user_roles.role_id").
+        Where("user_roles.user_id IN ?", uids).
+        Order("roles.id").
+        Scan(&rows).Error
+    if err != nil {
+        return nil, err
+    }
+
+    names := make(map[uint64][]string, len(uids))
+    for _, row := range rows {
+        names[row.UserID] = append(names[row.UserID], row.Name)
+    }
+    return names, nil
+}
+
+// GrantsOf 一次读取用户的全部角色和权限
+func GrantsOf(uid uint64) (Grants, error) {
+    grants := Grants{Roles: map[string]bool{}, Permissions: map[string]bool{}}
+
+    var roles []Role
+    err := model.DB.Preload("Permissions").
+        Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+        Where("user_roles.user_id = ?", uid).
+        Find(&roles).Error
+    if err != nil {
+        logger.LogError(err)
+        return grants, err
+    }
+
+    for _, _role := range roles {
+        grants.Roles[_role.Name] = true
+        for _, permission := range _role.Permissions {
+            grants.Permissions[permission.Name] = true
+        }
+    }
+    return grants, nil
+}
+
+// Sync 将用户的角色设置为 names，原有的其他角色将被移除
+func Sync(uid uint64, names ...string) error {
+    // 表单可能重复提交同一角色，去重后再与查到的角色数比较
+    seen := make(map[string]bool, len(names))
+    unique := make([]string, 0, len(names))
+    for _, name := range names {
+        if !seen[name] {
+            seen[name] = true
+            unique = append(unique, name)
+        }
+    }
+    names = unique
+
+    var roles []Role
+    if err := model.DB.Where("name IN ?", names).Find(&roles).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+    if len(roles) != len(names) {
+        return fmt.Errorf("角色不存在：%v", names)
+    }
+
+    err := model.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Where("user_id = ?", uid).Delete(&UserRole{}).Error; err != nil {
+            return err
+        }
+        for _, _role := range roles {
+            if err := tx.Create(&UserRole{UserID: uid, RoleID: _role.ID}).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        logger.LogError(err)
+    }
+    return err
+}
+
+// AssignDefault 为新用户分配 auth.default_role 配置的默认角色
+func AssignDefault(uid uint64) error {
+    return Sync(uid, config.GetString("auth.default_role"))
+}
@@ -0,0 +1,60 @@
+package role
+
+import "goblog/app/models"
+
+// 内置角色
+const (
+    Admin  = "admin"
+    Editor = "editor"
+    Author = "author"
+    Reader = "reader"
+)
+
+// 内置权限，权限名同时也是 policies.Can 检查的能力名
+const (
+    PermissionCreateArticles   = "articles.create"
+    PermissionUpdateArticles   = "articles.update"
+    PermissionDeleteArticles   = "articles.delete"
+    PermissionModerateComments = "comments.moderate"
+    PermissionManageUsers      = "users.manage"
+)
+
+// Role 角色，用户通过角色获得权限
+type Role struct {
+    models.BaseModel
+
+    Name  string `gorm:"type:varchar(191);not null;unique"`
+    Label string `gorm:"type:varchar(255);not null"`
+
+    Permissions []Permission `gorm:"many2many:role_permissions"`
+}
+
+// Permission 权限，对应一项能力
+type Permission struct {
+    models.BaseModel
+
+    Name  string `gorm:"type:varchar(191);not null;unique"`
+    Label string `gorm:"type:varchar(255);not null"`
+}
+
+// UserRole 用户和角色的多对多关联表 user_roles
+type UserRole struct {
+    UserID uint64 `gorm:"primaryKey;autoIncrement:false"`
+    RoleID uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+// Grants 用户通过角色获得的角色名和权限名集合
+type Grants struct {
+    Roles       map[string]bool
+    Permissions map[string]bool
+}
+
+// HasPermission 角色是否拥有 name 权限，需已预加载 Permissions
+func (r Role) HasPermission(name string) bool {
+    for _, permission := range r.Permissions {
+        if permission.Name == name {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,106 @@
+package tag
+
+import (
+	"fmt"
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+	"hash/crc32"
+	"math"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// GetBySlug 通过 URL 别名获取标签
+func GetBySlug(slug string) (Tag, error) {
+    var tag Tag
+    if err := model.DB.Where("slug = ?", slug).First(&tag).Error; err != nil {
+        return tag, err
+    }
+
+    return tag, nil
+}
+
+// FirstOrCreateByNames 按名称获取标签，不存在的标签自动创建，名称不区分大小写，
+// db 传入事务以便与文章的保存一同提交
+func FirstOrCreateByNames(db *gorm.DB, names []string) ([]Tag, error) {
+    tags := make([]Tag, 0, len(names))
+    for _, name := range names {
+        tag, err := firstByName(db, name)
+        if err != nil {
+            return tags, err
+        }
+        if tag.ID == 0 {
+            tag = Tag{Name: name, Slug: uniqueSlug(db, name)}
+            if err := db.Create(&tag).Error; err != nil {
+                // 并发请求可能已创建了同名标签，违反唯一约束时重新获取
+                existing, findErr := firstByName(db, name)
+                if findErr != nil || existing.ID == 0 {
+                    logger.LogError(err)
+                    return tags, err
+                }
+                tag = existing
+            }
+        }
+        tags = append(tags, tag)
+    }
+    return tags, nil
+}
+
+// firstByName 按名称获取标签，不存在时返回零值
+func firstByName(db *gorm.DB, name string) (Tag, error) {
+    var tag Tag
+    err := db.Where("LOWER(name) = LOWER(?)", name).Limit(1).Find(&tag).Error
+    return tag, err
+}
+
+// uniqueSlug 别名为空或已被其他标签占用时（如 C 和 C++），追加名称的校验值
+func uniqueSlug(db *gorm.DB, name string) string {
+    slug := MakeSlug(name)
+    if slug != "" {
+        var count int64
+        db.Model(&Tag{}).Where("slug = ?", slug).Count(&count)
+        if count == 0 {
+            return slug
+        }
+        slug += "-"
+    }
+    return slug + fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(name)))
+}
+
+// CloudTag 标签云中的标签，Weight 为 1~5 的权重，用以决定字号
+type CloudTag struct {
+    Tag
+    Weight int
+}
+
+// Cloud 获取使用次数最多的 limit 个标签，按使用次数计算权重，按名称排序
+func Cloud(limit int) ([]CloudTag, error) {
+    var tags []Tag
+    err := model.DB.Model(&Tag{}).
+        Select("tags.*, COUNT(article_tags.article_id) AS articles_count").
+        Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+        Group("tags.id").
+        Order("articles_count DESC, tags.id").
+        Limit(limit).
+        Find(&tags).Error
+    if err != nil || len(tags) == 0 {
+        return nil, err
+    }
+
+    // 按使用次数的对数线性映射到 1~5，避免个别热门标签把其余标签都压到最小
+    min, max := math.Log(float64(tags[len(tags)-1].ArticlesCount)), math.Log(float64(tags[0].ArticlesCount))
+    cloud := make([]CloudTag, len(tags))
+    for i, tag := range tags {
+        weight := 3
+        if max > min {
+            weight = 1 + int(math.Round((math.Log(float64(tag.ArticlesCount))-min)/(max-min)*4))
+        }
+        cloud[i] = CloudTag{Tag: tag, Weight: weight}
+    }
+
+    sort.Slice(cloud, func(i, j int) bool {
+        return cloud[i].Name < cloud[j].Name
+    })
+    return cloud, nil
+}
@@ -0,0 +1,87 @@
+package tag
+
+import (
+	"fmt"
+	"goblog/app/models"
+	"goblog/pkg/route"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+    // MaxTags 每篇文章最多的标签数
+    MaxTags = 5
+
+    // MaxNameLength 标签名称的最大长度，按字符计算
+    MaxNameLength = 20
+)
+
+// Tag 文章标签，与文章通过 article_tags 表多对多关联
+type Tag struct {
+    models.BaseModel
+
+    Name string `gorm:"type:varchar(191);not null;unique" valid:"name"`
+    Slug string `gorm:"type:varchar(191);not null;unique" valid:"slug"`
+
+    // ArticlesCount 使用该标签的文章数，只读字段，由 Cloud 查询填充
+    ArticlesCount int64 `gorm:"->"`
+}
+
+// Link 方法用来生成标签链接
+func (t Tag) Link() string {
+    return route.RouteName2URL("tags.show", "slug", t.Slug)
+}
+
+// ParseNames 解析以逗号分隔的标签输入，支持中英文逗号，去掉空白和重复项（不区分大小写）
+func ParseNames(input string) []string {
+    fields := strings.FieldsFunc(input, func(r rune) bool {
+        return r == ',' || r == '，'
+    })
+
+    seen := make(map[string]bool)
+    var names []string
+    for _, field := range fields {
+        name := strings.Join(strings.Fields(field), " ")
+        key := strings.ToLower(name)
+        if name == "" || seen[key] {
+            continue
+        }
+        seen[key] = true
+        names = append(names, name)
+    }
+    return names
+}
+
+// ValidateNames 检查标签数量和长度，返回错误消息，用于文章表单验证
+func ValidateNames(names []string) []string {
+    var errs []string
+    if len(names) > MaxTags {
+        errs = append(errs, fmt.Sprintf("标签数量不能超过 %d 个", MaxTags))
+    }
+    for _, name := range names {
+        if utf8.RuneCountInString(name) > MaxNameLength {
+            errs = append(errs, fmt.Sprintf("标签「%s」长度不能超过 %d", name, MaxNameLength))
+        }
+    }
+    return errs
+}
+
+// MakeSlug 生成标签的 URL 别名，保留字母和数字（含中文），空白转换为 -，其余字符去掉
+func MakeSlug(name string) string {
+    var b strings.Builder
+    dash := false
+    for _, r := range strings.ToLower(name) {
+        switch {
+        case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+            if dash && b.Len() > 0 {
+                b.WriteRune('-')
+            }
+            dash = false
+            b.WriteRune(r)
+        default:
+            dash = true
+        }
+    }
+    return b.String()
+}
@@ -0,0 +1,75 @@
+package token
+
+import (
+	"goblog/pkg/logger"
+	"goblog/pkg/model"
+	"goblog/pkg/types"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Create 生成令牌并保存摘要，返回仅此一次可见的明文令牌
+func (t *PersonalAccessToken) Create() (plainText string, err error) {
+    if plainText, err = generatePlainText(); err != nil {
+        logger.LogError(err)
+        return "", err
+    }
+    t.TokenHash = Hash(plainText)
+
+    if err = model.DB.Create(&t).Error; err != nil {
+        logger.LogError(err)
+        return "", err
+    }
+
+    return plainText, nil
+}
+
+// GetByUserID 获取用户的全部令牌，新创建的在前
+func GetByUserID(uid uint64) ([]PersonalAccessToken, error) {
+    var tokens []PersonalAccessToken
+    err := model.DB.Where("user_id = ?", uid).Order("created_at DESC, id DESC").Find(&tokens).Error
+    return tokens, err
+}
+
+// GetForUser 获取属于指定用户的令牌，不属于该用户时返回 gorm.ErrRecordNotFound
+func GetForUser(idstr string, uid uint64) (PersonalAccessToken, error) {
+    var t PersonalAccessToken
+    err := model.DB.Where("user_id = ?", uid).First(&t, types.StringToUint64(idstr)).Error
+    return t, err
+}
+
+// FindByPlainText 根据明文令牌查找令牌及其所属用户
+func FindByPlainText(plainText string) (PersonalAccessToken, error) {
+    var t PersonalAccessToken
+    if err := model.DB.Preload("User").Where("token_hash = ?", Hash(plainText)).First(&t).Error; err != nil {
+        return t, err
+    }
+    // 用户已被删除
+    if t.User.ID == 0 {
+        return t, gorm.ErrRecordNotFound
+    }
+    return t, nil
+}
+
+// Touch 记录令牌的最后使用时间，不更新 updated_at
+func (t *PersonalAccessToken) Touch() error {
+    now := time.Now()
+    t.LastUsedAt = &now
+    if err := model.DB.Model(t).UpdateColumn("last_used_at", now).Error; err != nil {
+        logger.LogError(err)
+        return err
+    }
+    return nil
+}
+
+// Delete 撤销令牌
+func (t *PersonalAccessToken) Delete() (rowsAffected int64, err error) {
+    result := model.DB.Delete(&t)
+    if err = result.Error; err != nil {
+        logger.LogError(err)
+        return 0, err
+    }
+
+    return result.RowsAffected, nil
+}
@@ -0,0 +1,94 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"goblog/app/models"
+	"goblog/app/models/user"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 令牌的权限范围，read 可调用只读接口，write 可创建、修改和删除
+const (
+    ScopeRead  = "read"
+    ScopeWrite = "write"
+)
+
+// Scopes 全部可选的权限范围，按显示顺序排列
+var Scopes = []string{ScopeRead, ScopeWrite}
+
+// prefix 明文令牌的前缀，便于在日志和代码仓库中识别泄露的令牌
+const prefix = "gb_"
+
+// PersonalAccessToken 个人访问令牌，只保存明文令牌的 SHA-256 摘要，明文仅在创建时显示一次
+type PersonalAccessToken struct {
+    models.BaseModel
+
+    UserID     uint64 `gorm:"not null;index"`
+    Name       string `gorm:"type:varchar(255);not null" valid:"name"`
+    TokenHash  string `gorm:"type:char(64);not null;unique"`
+    Scopes     string `gorm:"type:varchar(255);not null" valid:"scopes"`
+    LastUsedAt *time.Time
+
+    // User 需位于 Name 之后：表单验证时同名的 valid 标签以先出现的字段为准
+    User user.User
+}
+
+// Can 令牌是否具备 scope 权限
+func (t PersonalAccessToken) Can(scope string) bool {
+    for _, s := range t.ScopeList() {
+        if s == scope {
+            return true
+        }
+    }
+    return false
+}
+
+// ScopeList 令牌的权限范围列表
+func (t PersonalAccessToken) ScopeList() []string {
+    if t.Scopes == "" {
+        return nil
+    }
+    return strings.Split(t.Scopes, ",")
+}
+
+// LastUsedDate 最后使用时间，从未使用时返回空字符串
+func (t PersonalAccessToken) LastUsedDate() string {
+    if t.LastUsedAt == nil {
+        return ""
+    }
+    return t.LastUsedAt.Format("2006-01-02 15:04")
+}
+
+// CreatedAtDate 创建时间
+func (t PersonalAccessToken) CreatedAtDate() string {
+    return t.CreatedAt.Format("2006-01-02 15:04")
+}
+
+// ScopeForMethod 调用 HTTP 方法所需的权限范围，只读方法需要 read，其余需要 write
+func ScopeForMethod(method string) string {
+    switch method {
+    case http.MethodGet, http.MethodHead, http.MethodOptions:
+        return ScopeRead
+    default:
+        return ScopeWrite
+    }
+}
+
+// Hash 计算明文令牌的摘要
+func Hash(plainText string) string {
+    sum := sha256.Sum256([]byte(plainText))
+    return hex.EncodeToString(sum[:])
+}
+
+// generatePlainText 生成随机的明文令牌
+func generatePlainText() (string, error) {
+    b := make([]byte, 20)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return prefix + hex.EncodeToString(b), nil
+}
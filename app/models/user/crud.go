@@ -1,9 +1,14 @@
 package user
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"goblog/pkg/logger"
 	"goblog/pkg/model"
+	"goblog/pkg/pagination"
 	"goblog/pkg/types"
+	"net/http"
+	"time"
 )
 
 func (user *User) Create() (err error){
@@ -24,6 +29,16 @@ func GetByEmail(email string) (User, error) {
 	return user, nil
 }
 
+// GetByName 通过用户名来获取用户
+func GetByName(name string) (User, error) {
+	var user User
+	if err := model.DB.Where("name = ?", name).First(&user).Error; err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
 // Get 通过 ID 获取用户
 func Get(idstr string) (User, error) {
 	var user User
@@ -33,4 +48,73 @@ func Get(idstr string) (User, error) {
 	}
 
 	return user, nil
-}
\ No newline at end of file
+}
+
+// Count 用户总数
+func Count() (int64, error) {
+	var count int64
+	err := model.DB.Model(&User{}).Count(&count).Error
+	return count, err
+}
+
+// Paginate 按注册时间倒序分页获取用户
+func Paginate(r *http.Request, perPage int, routeName string, pars ...string) ([]User, pagination.ViewData, error) {
+	var users []User
+	_pager := pagination.New(r, model.DB.Model(&User{}).Order("id DESC"), perPage, routeName, pars...)
+	viewData := _pager.Paging()
+
+	err := _pager.Results(&users)
+	return users, viewData, err
+}
+
+// GetForSitemap 按 ID 顺序分段获取用户，只读取生成链接需要的字段，用于站点地图
+func GetForSitemap(offset int, limit int) ([]User, error) {
+	var users []User
+	err := model.DB.Select("id", "updated_at").Order("id").Offset(offset).Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// Update 保存用户信息，修改密码时由 BeforeSave 钩子加密
+func (user *User) Update() (rowsAffected int64, err error) {
+	result := model.DB.Save(&user)
+	if err = result.Error; err != nil {
+		logger.LogError(err)
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkEmailAsVerified 将邮箱标记为已验证
+func (user *User) MarkEmailAsVerified() error {
+	now := time.Now()
+	if err := model.DB.Model(user).Update("email_verified_at", now).Error; err != nil {
+		logger.LogError(err)
+		return err
+	}
+	user.EmailVerifiedAt = &now
+	return nil
+}
+
+// CycleRememberToken 重新生成记住登录的密钥，使所有设备上的记住登录失效
+func (user *User) CycleRememberToken() error {
+	token, err := newRememberToken()
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	if err := model.DB.Model(user).UpdateColumn("remember_token", token).Error; err != nil {
+		logger.LogError(err)
+		return err
+	}
+	user.RememberToken = token
+	return nil
+}
+
+// newRememberToken 生成随机的“记住我”密钥
+func newRememberToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -9,8 +9,10 @@ import (
 // BeforeSave GORM 的模型钩子，在保存和更新模型前调用
 func (u *User) BeforeSave(tx *gorm.DB) (err error) {
 
+    // 密码被修改时加密保存，并重新生成“记住我”的密钥，使其他设备上的记住登录失效
     if !password.IsHashed(u.Password) {
         u.Password = password.Hash(u.Password)
+        u.RememberToken, err = newRememberToken()
     }
     return
 }
\ No newline at end of file
@@ -1,9 +1,12 @@
 package user
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"goblog/app/models"
 	"goblog/pkg/password"
 	"goblog/pkg/route"
+	"time"
 )
 
 // User 用户模型
@@ -14,6 +17,12 @@ type User struct {
     Email    string `gorm:"type:varchar(191);unique" valid:"email"`
     Password string `gorm:"type:varchar(191);" valid:"password"`
 
+    // EmailVerifiedAt 邮箱验证时间，为空表示未验证
+    EmailVerifiedAt *time.Time
+
+    // RememberToken “记住我”的密钥，Cookie 中只保存由它计算的签名，重新生成后所有设备的记住登录失效
+    RememberToken string `gorm:"type:varchar(64)"`
+
     // gorm:"-" —— 设置 GORM 在读写时略过此字段，仅用于表单验证
     PasswordConfirm string `gorm:"-" valid:"password_confirm"`
 }
@@ -26,4 +35,15 @@ func (u User) ComparePassword(_password string) bool  {
 // Link 方法用来生成用户链接
 func (u User) Link() string {
     return route.RouteName2URL("users.show", "id", u.GetStringID())
-}
\ No newline at end of file
+}
+
+// IsVerified 邮箱是否已验证
+func (u User) IsVerified() bool {
+    return u.EmailVerifiedAt != nil
+}
+
+// EmailHash 邮箱的摘要，用于验证链接，修改邮箱后旧的验证链接随之失效
+func (u User) EmailHash() string {
+    sum := sha256.Sum256([]byte(u.Email))
+    return hex.EncodeToString(sum[:])
+}
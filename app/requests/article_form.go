@@ -2,6 +2,7 @@ package requests
 
 import (
     "goblog/app/models/article"
+    "goblog/app/models/tag"
 
     "github.com/thedevsaddam/govalidator"
 )
@@ -13,6 +14,8 @@ func ValidateArticleForm(data article.Article) map[string][]string {
     rules := govalidator.MapData{
         "title": []string{"required", "min:3", "max:40"},
         "body":  []string{"required", "min:10"},
+
+        "category_id": []string{"exists:categories,id"},
     }
 
     // 2. 定制错误消息
@@ -26,6 +29,9 @@ func ValidateArticleForm(data article.Article) map[string][]string {
             "required:文章内容为必填项",
             "min:长度需大于 10",
         },
+        "category_id": []string{
+            "exists:所选分类不存在",
+        },
     }
 
     // 3. 配置初始化
@@ -37,5 +43,12 @@ func ValidateArticleForm(data article.Article) map[string][]string {
     }
 
     // 4. 开始验证
-    return govalidator.New(opts).ValidateStruct()
+    errs := govalidator.New(opts).ValidateStruct()
+
+    // 5. 标签以逗号分隔，govalidator 无法按单个标签验证，我们自己写
+    if tagErrs := tag.ValidateNames(tag.ParseNames(data.TagNames)); len(tagErrs) > 0 {
+        errs["tags"] = append(errs["tags"], tagErrs...)
+    }
+
+    return errs
 }
\ No newline at end of file
@@ -0,0 +1,51 @@
+package requests
+
+import (
+    "goblog/app/models/comment"
+    "goblog/pkg/types"
+
+    "github.com/thedevsaddam/govalidator"
+)
+
+// ValidateCommentForm 验证评论表单，返回 errs 长度等于零即通过
+func ValidateCommentForm(data comment.Comment) map[string][]string {
+
+    // 1. 定制认证规则
+    rules := govalidator.MapData{
+        "body":      []string{"required", "min:2", "max:1000"},
+        "parent_id": []string{"exists:comments,id"},
+    }
+
+    // 2. 定制错误消息
+    messages := govalidator.MapData{
+        "body": []string{
+            "required:评论内容为必填项",
+            "min:评论内容长度需大于 2",
+            "max:评论内容长度需小于 1000",
+        },
+        "parent_id": []string{
+            "exists:回复的评论不存在",
+        },
+    }
+
+    // 3. 配置初始化
+    opts := govalidator.Options{
+        Data:          &data,
+        Rules:         rules,
+        TagIdentifier: "valid", // 模型中的 Struct 标签标识符
+        Messages:      messages,
+    }
+
+    // 4. 开始验证
+    errs := govalidator.New(opts).ValidateStruct()
+
+    // 5. 回复的评论需属于同一篇文章
+    if data.ParentID > 0 && len(errs["parent_id"]) == 0 {
+        parent, err := comment.Get(types.Uint64ToString(data.ParentID))
+        if err != nil || parent.ArticleID != data.ArticleID {
+            errs["parent_id"] = append(errs["parent_id"], "回复的评论不存在")
+        }
+    }
+
+    return errs
+}
@@ -32,4 +32,29 @@ func init() {
         }
         return nil
     })
+
+    // exists:categories,id，值为空或 0 时跳过，用于可选的关联字段
+    govalidator.AddCustomRule("exists", func(field string, rule string, message string, value interface{}) error {
+        rng := strings.Split(strings.TrimPrefix(rule, "exists:"), ",")
+
+        tableName := rng[0]
+        dbFiled := rng[1]
+        val := fmt.Sprint(value)
+        if val == "" || val == "0" {
+            return nil
+        }
+
+        var count int64
+        model.DB.Table(tableName).Where(dbFiled+" = ?", val).Count(&count)
+
+        if count == 0 {
+
+            if message != "" {
+                return errors.New(message)
+            }
+
+            return fmt.Errorf("%v 不存在", val)
+        }
+        return nil
+    })
 }
\ No newline at end of file
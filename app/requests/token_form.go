@@ -0,0 +1,58 @@
+package requests
+
+import (
+    "goblog/app/models/token"
+
+    "github.com/thedevsaddam/govalidator"
+)
+
+// ValidateTokenForm 验证个人访问令牌表单，返回 errs 长度等于零即通过
+func ValidateTokenForm(data token.PersonalAccessToken) map[string][]string {
+
+    // 1. 定制认证规则
+    rules := govalidator.MapData{
+        "name":   []string{"required", "max:50"},
+        "scopes": []string{"required"},
+    }
+
+    // 2. 定制错误消息
+    messages := govalidator.MapData{
+        "name": []string{
+            "required:令牌名称为必填项",
+            "max:令牌名称长度需小于 50",
+        },
+        "scopes": []string{
+            "required:请至少选择一项权限",
+        },
+    }
+
+    // 3. 配置初始化
+    opts := govalidator.Options{
+        Data:          &data,
+        Rules:         rules,
+        TagIdentifier: "valid", // 模型中的 Struct 标签标识符
+        Messages:      messages,
+    }
+
+    // 4. 开始验证
+    errs := govalidator.New(opts).ValidateStruct()
+
+    // 5. 权限范围需为可选值
+    for _, scope := range data.ScopeList() {
+        if !isValidScope(scope) {
+            errs["scopes"] = append(errs["scopes"], "权限范围无效")
+            break
+        }
+    }
+
+    return errs
+}
+
+func isValidScope(scope string) bool {
+    for _, s := range token.Scopes {
+        if s == scope {
+            return true
+        }
+    }
+    return false
+}
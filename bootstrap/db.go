@@ -1,15 +1,12 @@
 package bootstrap
 
 import (
-	"goblog/app/models/article"
-	"goblog/app/models/user"
 	"goblog/pkg/config"
 	"goblog/pkg/model"
 	"time"
-
-	"gorm.io/gorm"
 )
 
+// SetUpDB 初始化数据库连接，数据表结构通过 migrate 命令维护
 func SetUpDB() {
 	// 建立数据库连接池
     db := model.ConnectDB()
@@ -17,22 +14,13 @@ func SetUpDB() {
     // 命令行打印数据库请求的信息
     sqlDB, _ := db.DB()
 
+    // 连接池配置按当前连接读取，如 database.sqlite.max_open_connections
+    prefix := "database." + model.Connection() + "."
+
     // 设置最大连接数
-    sqlDB.SetMaxOpenConns(config.GetInt("database.mysql.max_open_connections"))
+    sqlDB.SetMaxOpenConns(config.GetInt(prefix + "max_open_connections"))
     // 设置最大空闲连接数
-    sqlDB.SetMaxIdleConns(config.GetInt("database.mysql.max_idle_connections"))
+    sqlDB.SetMaxIdleConns(config.GetInt(prefix + "max_idle_connections"))
     // 设置每个链接的过期时间
-    sqlDB.SetConnMaxLifetime(time.Duration(config.GetInt("database.mysql.max_life_seconds")) * time.Second)
-    
-    // 创建和维护数据表结构
-    migration(db)
+    sqlDB.SetConnMaxLifetime(time.Duration(config.GetInt(prefix + "max_life_seconds")) * time.Second)
 }
-
-func migration(db *gorm.DB) {
-
-    // 自动迁移
-    db.AutoMigrate(
-        &user.User{},
-        &article.Article{},
-    )
-}
\ No newline at end of file
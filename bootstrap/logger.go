@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+)
+
+// SetupLogger 初始化 Logger
+func SetupLogger() {
+    logger.InitLogger(logger.Options{
+        Level:     config.GetString("log.level"),
+        Format:    config.GetString("log.format"),
+        Channel:   config.GetString("log.channel"),
+        Filename:  config.GetString("log.filename"),
+        MaxSize:   config.GetInt("log.max_size"),
+        MaxBackup: config.GetInt("log.max_backup"),
+        MaxAge:    config.GetInt("log.max_age"),
+        Compress:  config.GetBool("log.compress"),
+    })
+}
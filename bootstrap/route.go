@@ -10,6 +10,7 @@ import (
 func SetupRoute() *mux.Router {
 	router := mux.NewRouter()
     route.SetRoute(router)
+	routes.RegisterAPIRoutes(router)
 	routes.RegisterWebRoutes(router)
 	return router
 }
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"goblog/app/models/article"
+	"goblog/pkg/model"
+	"goblog/pkg/search"
+
+	"gorm.io/gorm"
+)
+
+// SetupSearch 初始化搜索引擎，使用进程内索引时从数据库读取全部文章重建索引
+func SetupSearch() error {
+    rebuilder, ok := search.Default().(search.Rebuilder)
+    if !ok {
+        return nil
+    }
+
+    var docs []search.Document
+    var articles []article.Article
+    err := model.DB.FindInBatches(&articles, 500, func(tx *gorm.DB, batch int) error {
+        for _, a := range articles {
+            docs = append(docs, a.SearchDocument())
+        }
+        return nil
+    }).Error
+    if err != nil {
+        return err
+    }
+    return rebuilder.Rebuild(docs)
+}
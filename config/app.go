@@ -5,7 +5,7 @@ import "goblog/pkg/config"
 func init() {
     config.Add("app", config.StrMap{
 
-        // 应用名称，暂时没有使用到
+        // 应用名称，用作订阅源的标题
         "name": config.Env("APP_NAME", "GoBlog"),
 
         // 当前环境，用以区分多环境
@@ -17,7 +17,14 @@ func init() {
         // 应用服务端口
         "port": config.Env("APP_PORT", "3000"),
 
-        // gorilla/sessions 在 Cookie 中加密数据时使用
-        "key": config.Env("APP_KEY", "33446a9dcf9ea060a0a6532b166da32f304af0de"),
+        // 站点的访问地址，用以生成订阅源等场景中的绝对链接
+        "url": config.Env("APP_URL", "http://localhost:3000"),
+
+        // gorilla/sessions 在 Cookie 中加密数据时使用，通过 key:generate 命令生成
+        "key": config.Env("APP_KEY", ""),
+
+        // 受信任的反向代理，逗号分隔的 IP 或 CIDR，如 127.0.0.1,10.0.0.0/8。
+        // 只有来自这些地址的请求才读取 X-Forwarded-For 标头获取客户端 IP
+        "trusted_proxies": config.Env("APP_TRUSTED_PROXIES", ""),
     })
 }
\ No newline at end of file
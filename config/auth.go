@@ -0,0 +1,17 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("auth", config.StrMap{
+
+        // 密码重置链接的有效期，单位为分钟
+        "password_reset_expire": config.Env("AUTH_PASSWORD_RESET_EXPIRE", 60),
+
+        // 邮箱验证链接的有效期，单位为分钟
+        "verification_expire": config.Env("AUTH_VERIFICATION_EXPIRE", 1440),
+
+        // 新注册用户的默认角色：admin、editor、author 或 reader
+        "default_role": config.Env("AUTH_DEFAULT_ROLE", "author"),
+    })
+}
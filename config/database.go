@@ -7,6 +7,10 @@ import (
 func init() {
 
     config.Add("database", config.StrMap{
+
+        // 默认数据库连接，支持 mysql 和 sqlite
+        "connection": config.Env("DB_CONNECTION", "mysql"),
+
         "mysql": map[string]interface{}{
 
             // 数据库连接信息
@@ -22,5 +26,18 @@ func init() {
             "max_open_connections": config.Env("DB_MAX_OPEN_CONNECTIONS", 25),
             "max_life_seconds":     config.Env("DB_MAX_LIFE_SECONDS", 5*60),
         },
+
+        "sqlite": map[string]interface{}{
+
+            // 数据库文件路径，使用 :memory: 则为内存数据库（适合测试）
+            "database": config.Env("DB_SQL_FILE", "database/database.db"),
+
+            // 连接池配置，SQLite 同一时间只允许一个写入连接，内存数据库的每个连接各是一个独立的库，
+            // 因此固定为一个连接，不读取为 MySQL 设置的 DB_MAX_* 环境变量
+            "max_idle_connections": 1,
+            "max_open_connections": 1,
+            // 0 表示连接不过期，内存数据库的连接被回收后数据会丢失
+            "max_life_seconds": config.Env("DB_SQLITE_MAX_LIFE_SECONDS", 0),
+        },
     })
-}
\ No newline at end of file
+}
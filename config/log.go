@@ -0,0 +1,40 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("log", config.StrMap{
+
+        // 日志级别，必须是以下这些选项：
+        // "debug" —— 信息量大，一般调试时打开。系统模块详细运行的日志，例如 HTTP 请求、数据库请求等
+        // "info" —— 业务级别的运行日志，如用户登录、用户退出、订单撤销
+        // "warn" —— 感兴趣、需要引起关注的信息，例如调试时候打印调试信息
+        // "error" —— 记录错误信息，程序出错时记录，不会中断程序运行
+        "level": config.Env("APP_LOG_LEVEL", "info"),
+
+        // 日志格式，可选：
+        // "text" —— 便于阅读的文本格式，适合开发环境
+        // "json" —— 便于日志系统收集分析，适合生产环境
+        "format": config.Env("LOG_FORMAT", "text"),
+
+        // 日志的输出位置，可选：
+        // "stdout" —— 输出到终端
+        // "file" —— 写入 filename 指定的文件，按大小自动切割
+        "channel": config.Env("LOG_CHANNEL", "stdout"),
+
+        // 日志文件路径
+        "filename": config.Env("LOG_NAME", "storage/logs/goblog.log"),
+
+        // 每个日志文件保存的最大尺寸，单位：M
+        "max_size": config.Env("LOG_MAX_SIZE", 64),
+
+        // 最多保存日志文件数，0 为不限，MaxAge 到了还是会删
+        "max_backup": config.Env("LOG_MAX_BACKUP", 5),
+
+        // 最多保存多少天，0 表示不删
+        "max_age": config.Env("LOG_MAX_AGE", 30),
+
+        // 是否压缩，压缩日志不方便查看，我们设置为 false
+        "compress": config.Env("LOG_COMPRESS", false),
+    })
+}
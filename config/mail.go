@@ -0,0 +1,25 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("mail", config.StrMap{
+
+        // 邮件驱动，支持 smtp、log（写入日志，不真正发送）和 memory（保存在内存中，用于测试）
+        "default": config.Env("MAIL_DRIVER", "log"),
+
+        // 默认发件人
+        "from": map[string]interface{}{
+            "address": config.Env("MAIL_FROM_ADDRESS", "noreply@example.com"),
+            "name":    config.Env("MAIL_FROM_NAME", config.Env("APP_NAME", "GoBlog")),
+        },
+
+        // SMTP 服务器
+        "smtp": map[string]interface{}{
+            "host":     config.Env("MAIL_HOST", "localhost"),
+            "port":     config.Env("MAIL_PORT", 587),
+            "username": config.Env("MAIL_USERNAME", ""),
+            "password": config.Env("MAIL_PASSWORD", ""),
+        },
+    })
+}
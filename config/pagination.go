@@ -0,0 +1,14 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("pagination", config.StrMap{
+
+        // 默认每页条数
+        "perpage": config.Env("PAGINATION_PERPAGE", 10),
+
+        // URL 中用以区分页码的参数，如 ?page=2
+        "url_query": "page",
+    })
+}
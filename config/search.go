@@ -0,0 +1,12 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("search", config.StrMap{
+
+        // 搜索驱动，支持 mysql（FULLTEXT 索引）和 memory（进程内倒排索引），
+        // 留空时 MySQL 数据库使用 mysql，其他数据库使用 memory
+        "driver": config.Env("SEARCH_DRIVER", ""),
+    })
+}
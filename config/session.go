@@ -6,10 +6,25 @@ import "goblog/pkg/config"
 func init() {
 	config.Add("session", config.StrMap{
 
-        // 目前只支持 Cookie
+        // 会话驱动，支持 cookie、database 和 file
         "default": config.Env("SESSION_DRIVER", "cookie"),
 
         // 会话的 Cookie 名称
         "session_name": config.Env("SESSION_NAME", "goblog-session"),
+
+        // 会话有效期，单位为分钟，过期后服务端不再认可此会话
+        "lifetime": config.Env("SESSION_LIFETIME", 120),
+
+        // database 驱动使用的数据表
+        "table": config.Env("SESSION_TABLE", "sessions"),
+
+        // file 驱动存放会话文件的目录
+        "files": config.Env("SESSION_FILES", "storage/sessions"),
+
+        // “记住我”的 Cookie 名称
+        "remember_name": config.Env("SESSION_REMEMBER_NAME", "goblog-remember"),
+
+        // “记住我”的有效期，单位为分钟，默认 30 天
+        "remember_lifetime": config.Env("SESSION_REMEMBER_LIFETIME", 43200),
     })
-}
\ No newline at end of file
+}
@@ -0,0 +1,27 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("sitemap", config.StrMap{
+
+        // 单个 sitemap 文件的最大链接数，协议规定不超过 50000，超过后 /sitemap.xml 输出为索引文件
+        "max_urls": config.Env("SITEMAP_MAX_URLS", 50000),
+
+        // 加入 sitemap 的静态页面路由名称
+        "routes": []string{"home", "about"},
+    })
+
+    config.Add("robots", config.StrMap{
+
+        // 各环境下禁止抓取的路径，以逗号分隔，支持 * 通配符，按 app.env 读取，
+        // 未列出的环境使用 default，即禁止抓取整个站点，避免测试站点被搜索引擎收录。
+        // 嵌套配置需使用 map[string]interface{}，viper 才能以 robots.disallow.production 读取
+        "disallow": map[string]interface{}{
+            "default":    "/",
+            "local":      "/",
+            "testing":    "/",
+            "production": config.Env("ROBOTS_DISALLOW", "/auth/*,/articles/create,/search"),
+        },
+    })
+}
@@ -0,0 +1,33 @@
+package config
+
+import "goblog/pkg/config"
+
+func init() {
+    config.Add("throttle", config.StrMap{
+
+        // 失败记录的存储，支持 memory（仅限单实例）和 database（多实例共享）
+        "store": config.Env("THROTTLE_STORE", "memory"),
+
+        // 登录限制，同时按 Email 和 IP 计数
+        "login": map[string]interface{}{
+            // 同一 Email 连续失败达到此次数后锁定
+            "max_attempts": config.Env("LOGIN_MAX_ATTEMPTS", 5),
+            // 同一 IP 在 lockout 秒内的登录尝试（含成功的）达到此次数后锁定，不退避，避免误伤共用 IP 的用户
+            "max_attempts_per_ip": config.Env("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+            // 同一 Email 首次失败后的等待秒数，此后每次失败翻倍
+            "backoff": config.Env("LOGIN_BACKOFF", 1),
+            // 锁定秒数
+            "lockout": config.Env("LOGIN_LOCKOUT", 900),
+        },
+
+        // 发送重置密码邮件的频率限制，同时按 Email 和 IP 计数
+        "password_reset": map[string]interface{}{
+            // 同一 Email 在 window 秒内最多申请的次数
+            "max_attempts": config.Env("PASSWORD_RESET_MAX_ATTEMPTS", 3),
+            // 同一 IP 在 window 秒内最多申请的次数
+            "max_attempts_per_ip": config.Env("PASSWORD_RESET_MAX_ATTEMPTS_PER_IP", 10),
+            // 计数窗口秒数，达到上限后锁定同样时长
+            "window": config.Env("PASSWORD_RESET_WINDOW", 3600),
+        },
+    })
+}
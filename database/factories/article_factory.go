@@ -0,0 +1,49 @@
+package factories
+
+import (
+	"goblog/app/models/article"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// MakeArticles 生成 times 篇文章，作者从 userIDs 中随机选取，分类从 categoryIDs 中随机选取，未写入数据库
+func MakeArticles(times int, userIDs []uint64, categoryIDs []uint64) []article.Article {
+    var objs []article.Article
+
+    now := time.Now()
+    for i := 0; i < times; i++ {
+        // 发布时间分布在过去一年内，方便演示分页和按日期搜索
+        createdAt := gofakeit.DateRange(now.AddDate(-1, 0, 0), now)
+
+        model := article.Article{
+            Title: makeTitle(),
+            Body:  gofakeit.Paragraph(gofakeit.Number(2, 5), gofakeit.Number(3, 6), 12, "\n\n"),
+        }
+        model.CreatedAt = createdAt
+        model.UpdatedAt = createdAt
+        if len(userIDs) > 0 {
+            model.UserID = userIDs[gofakeit.Number(0, len(userIDs)-1)]
+        }
+        if len(categoryIDs) > 0 {
+            model.CategoryID = categoryIDs[gofakeit.Number(0, len(categoryIDs)-1)]
+        }
+        objs = append(objs, model)
+    }
+
+    return objs
+}
+
+// makeTitle 生成标题，满足文章表单 3~40 个字符的长度要求
+func makeTitle() string {
+    words := strings.Fields(strings.TrimSuffix(gofakeit.Sentence(gofakeit.Number(3, 8)), "."))
+    title := ""
+    for _, word := range words {
+        if len(title)+len(word)+1 > 40 {
+            break
+        }
+        title = strings.TrimSpace(title + " " + word)
+    }
+    return title
+}
@@ -0,0 +1,66 @@
+// Package factories 模型工厂，生成带有假数据的模型，用于数据填充和测试
+package factories
+
+import (
+	"fmt"
+	"goblog/app/models/user"
+	"goblog/pkg/password"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// DefaultPassword 工厂生成的用户的登录密码
+const DefaultPassword = "secret"
+
+var (
+    hashedPasswordOnce sync.Once
+    hashedPassword     string
+
+    nonAlphaNum = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+    // 常见邮箱域名，保证 Email 满足注册时的长度限制
+    emailDomains = []string{"gmail.com", "qq.com", "163.com", "outlook.com", "example.com"}
+)
+
+// defaultPasswordHash bcrypt 计算较慢，所有用户共用一次计算的哈希值。
+// 哈希过的密码会被 User 的 BeforeSave 钩子跳过，明文密码则由钩子加密
+func defaultPasswordHash() string {
+    hashedPasswordOnce.Do(func() {
+        hashedPassword = password.Hash(DefaultPassword)
+    })
+    return hashedPassword
+}
+
+// MakeUsers 生成 times 个邮箱已验证的用户，密码均为 DefaultPassword，未写入数据库
+func MakeUsers(times int) []user.User {
+    var objs []user.User
+    verifiedAt := time.Now()
+
+    for i := 0; i < times; i++ {
+        // 用户名需满足注册规则：字母和数字，长度 3~20
+        name := nonAlphaNum.ReplaceAllString(gofakeit.FirstName(), "")
+        name = fmt.Sprintf("%s%d", truncate(name, 12), gofakeit.Number(100, 99999))
+
+        model := user.User{
+            Name:     name,
+            Email:    strings.ToLower(name) + "@" + gofakeit.RandomString(emailDomains),
+            Password: defaultPasswordHash(),
+
+            EmailVerifiedAt: &verifiedAt,
+        }
+        objs = append(objs, model)
+    }
+
+    return objs
+}
+
+func truncate(s string, length int) string {
+    if len(s) > length {
+        return s[:length]
+    }
+    return s
+}
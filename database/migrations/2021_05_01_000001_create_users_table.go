@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type User struct {
+        models.BaseModel
+
+        Name     string `gorm:"type:varchar(191);not null;unique"`
+        Email    string `gorm:"type:varchar(191);unique"`
+        Password string `gorm:"type:varchar(191)"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&User{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&User{})
+    }
+
+    migrate.Add("2021_05_01_000001_create_users_table", up, down)
+}
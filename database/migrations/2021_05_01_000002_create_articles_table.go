@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type Article struct {
+        models.BaseModel
+
+        Title  string `gorm:"type:varchar(255);not null"`
+        Body   string `gorm:"type:longtext;not null"`
+        UserID uint64 `gorm:"not null;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Article{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&Article{})
+    }
+
+    migrate.Add("2021_05_01_000002_create_articles_table", up, down)
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 供 database 会话驱动使用，表名需与 session.table 配置一致
+    type Session struct {
+        ID           string `gorm:"type:varchar(64);primaryKey"`
+        UserID       string `gorm:"type:varchar(32);index"`
+        Payload      []byte
+        IPAddress    string `gorm:"type:varchar(45)"`
+        UserAgent    string `gorm:"type:varchar(255)"`
+        LastActivity time.Time
+        ExpiresAt    time.Time `gorm:"index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Session{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&Session{})
+    }
+
+    migrate.Add("2021_05_20_000001_create_sessions_table", up, down)
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type Category struct {
+        models.BaseModel
+
+        Name        string `gorm:"type:varchar(191);not null;unique"`
+        Description string `gorm:"type:varchar(255)"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Category{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&Category{})
+    }
+
+    migrate.Add("2021_06_01_000001_create_categories_table", up, down)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 0 表示未分类
+    type Article struct {
+        CategoryID uint64 `gorm:"not null;default:0;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        if err := db.Migrator().AddColumn(&Article{}, "CategoryID"); err != nil {
+            return err
+        }
+        return db.Migrator().CreateIndex(&Article{}, "CategoryID")
+    }
+
+    down := func(db *gorm.DB) error {
+        if err := db.Migrator().DropIndex(&Article{}, "CategoryID"); err != nil {
+            return err
+        }
+        return db.Migrator().DropColumn(&Article{}, "CategoryID")
+    }
+
+    migrate.Add("2021_06_01_000002_add_category_id_to_articles_table", up, down)
+}
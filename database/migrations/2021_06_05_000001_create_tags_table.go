@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type Tag struct {
+        models.BaseModel
+
+        Name string `gorm:"type:varchar(191);not null;unique"`
+        Slug string `gorm:"type:varchar(191);not null;unique"`
+    }
+
+    // ArticleTag 文章和标签的多对多关联表 article_tags
+    type ArticleTag struct {
+        ArticleID uint64 `gorm:"primaryKey;autoIncrement:false"`
+        TagID     uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Tag{}, &ArticleTag{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&ArticleTag{}, &Tag{})
+    }
+
+    migrate.Add("2021_06_05_000001_create_tags_table", up, down)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type Comment struct {
+        models.BaseModel
+
+        ArticleID uint64 `gorm:"not null;index"`
+        UserID    uint64 `gorm:"not null;index"`
+        ParentID  uint64 `gorm:"not null;default:0;index"`
+        Body      string `gorm:"type:text;not null"`
+        Status    string `gorm:"type:varchar(20);not null;default:pending;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Comment{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&Comment{})
+    }
+
+    migrate.Add("2021_06_10_000001_create_comments_table", up, down)
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+	"goblog/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 只有 MySQL 支持 FULLTEXT 索引，其他数据库使用进程内的索引搜索。
+    // ngram 分词器用于支持中文，需要 MySQL 5.7.6 及以上版本
+    up := func(db *gorm.DB) error {
+        if model.Connection() != "mysql" {
+            return nil
+        }
+        return db.Exec("ALTER TABLE articles ADD FULLTEXT INDEX idx_articles_fulltext (title, body) WITH PARSER ngram").Error
+    }
+
+    down := func(db *gorm.DB) error {
+        if model.Connection() != "mysql" {
+            return nil
+        }
+        return db.Exec("ALTER TABLE articles DROP INDEX idx_articles_fulltext").Error
+    }
+
+    migrate.Add("2021_06_15_000001_add_fulltext_index_to_articles_table", up, down)
+}
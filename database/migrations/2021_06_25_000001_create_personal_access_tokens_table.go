@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type PersonalAccessToken struct {
+        models.BaseModel
+
+        UserID     uint64 `gorm:"not null;index"`
+        Name       string `gorm:"type:varchar(255);not null"`
+        TokenHash  string `gorm:"type:char(64);not null;unique"`
+        Scopes     string `gorm:"type:varchar(255);not null"`
+        LastUsedAt *time.Time
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&PersonalAccessToken{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&PersonalAccessToken{})
+    }
+
+    migrate.Add("2021_06_25_000001_create_personal_access_tokens_table", up, down)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type PasswordReset struct {
+        models.BaseModel
+
+        UserID    uint64    `gorm:"not null;index"`
+        TokenHash string    `gorm:"type:char(64);not null;unique"`
+        ExpiresAt time.Time `gorm:"not null;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&PasswordReset{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&PasswordReset{})
+    }
+
+    migrate.Add("2021_07_01_000001_create_password_resets_table", up, down)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 为空表示邮箱未验证
+    type User struct {
+        EmailVerifiedAt *time.Time
+    }
+
+    up := func(db *gorm.DB) error {
+        if err := db.Migrator().AddColumn(&User{}, "EmailVerifiedAt"); err != nil {
+            return err
+        }
+        // 已有的用户视为已验证，避免升级后无法发布文章
+        return db.Exec("UPDATE users SET email_verified_at = created_at").Error
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropColumn(&User{}, "EmailVerifiedAt")
+    }
+
+    migrate.Add("2021_07_05_000001_add_email_verified_at_to_users_table", up, down)
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type User struct {
+        RememberToken string `gorm:"type:varchar(64)"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AddColumn(&User{}, "RememberToken")
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropColumn(&User{}, "RememberToken")
+    }
+
+    migrate.Add("2021_07_10_000001_add_remember_token_to_users_table", up, down)
+}
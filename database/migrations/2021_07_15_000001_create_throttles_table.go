@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"goblog/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 登录限制等失败计数，throttle.store 为 database 时使用
+    type Throttle struct {
+        Key         string    `gorm:"column:key;type:varchar(191);primaryKey"`
+        Attempts    int       `gorm:"column:attempts;not null;default:0"`
+        LockedUntil time.Time `gorm:"column:locked_until"`
+        ExpiresAt   time.Time `gorm:"column:expires_at;index"`
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Migrator().AutoMigrate(&Throttle{})
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&Throttle{})
+    }
+
+    migrate.Add("2021_07_15_000001_create_throttles_table", up, down)
+}
@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"goblog/app/models"
+	"goblog/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    type Role struct {
+        models.BaseModel
+
+        Name  string `gorm:"type:varchar(191);not null;unique"`
+        Label string `gorm:"type:varchar(255);not null"`
+    }
+
+    type Permission struct {
+        models.BaseModel
+
+        Name  string `gorm:"type:varchar(191);not null;unique"`
+        Label string `gorm:"type:varchar(255);not null"`
+    }
+
+    // RolePermission 角色和权限的多对多关联表 role_permissions
+    type RolePermission struct {
+        RoleID       uint64 `gorm:"primaryKey;autoIncrement:false"`
+        PermissionID uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+    }
+
+    // UserRole 用户和角色的多对多关联表 user_roles
+    type UserRole struct {
+        UserID uint64 `gorm:"primaryKey;autoIncrement:false"`
+        RoleID uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+    }
+
+    // 内置的权限和角色，admin 不受权限限制，此处仍分配全部权限以便在管理页面中展示
+    permissions := []Permission{
+        {Name: "articles.create", Label: "发布文章"},
+        {Name: "articles.update", Label: "编辑他人的文章"},
+        {Name: "articles.delete", Label: "删除他人的文章"},
+        {Name: "comments.moderate", Label: "审核他人文章下的评论"},
+        {Name: "users.manage", Label: "管理用户角色"},
+    }
+    roles := []struct {
+        Role
+        Permissions []string
+    }{
+        {Role{Name: "admin", Label: "管理员"}, []string{"articles.create", "articles.update", "articles.delete", "comments.moderate", "users.manage"}},
+        {Role{Name: "editor", Label: "编辑"}, []string{"articles.create", "articles.update", "articles.delete", "comments.moderate"}},
+        {Role{Name: "author", Label: "作者"}, []string{"articles.create"}},
+        {Role{Name: "reader", Label: "读者"}, []string{}},
+    }
+
+    up := func(db *gorm.DB) error {
+        return db.Transaction(func(tx *gorm.DB) error {
+            if err := tx.Migrator().AutoMigrate(&Role{}, &Permission{}, &RolePermission{}, &UserRole{}); err != nil {
+                return err
+            }
+
+            ids := map[string]uint64{}
+            for _, permission := range permissions {
+                if err := tx.Create(&permission).Error; err != nil {
+                    return err
+                }
+                ids[permission.Name] = permission.ID
+            }
+            for _, item := range roles {
+                _role := item.Role
+                if err := tx.Create(&_role).Error; err != nil {
+                    return err
+                }
+                for _, name := range item.Permissions {
+                    if err := tx.Create(&RolePermission{RoleID: _role.ID, PermissionID: ids[name]}).Error; err != nil {
+                        return err
+                    }
+                }
+            }
+
+            // 已有的用户都能发布文章，升级后保持不变，统一设为作者
+            return tx.Exec("INSERT INTO user_roles (user_id, role_id) SELECT users.id, roles.id FROM users, roles WHERE roles.name = ?", "author").Error
+        })
+    }
+
+    down := func(db *gorm.DB) error {
+        return db.Migrator().DropTable(&UserRole{}, &RolePermission{}, &Permission{}, &Role{})
+    }
+
+    migrate.Add("2021_07_20_000001_create_roles_tables", up, down)
+}
@@ -0,0 +1,30 @@
+package seeders
+
+import (
+	"goblog/database/factories"
+	"goblog/pkg/seed"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 添加 Seeder
+    seed.Add("SeedArticlesTable", func(db *gorm.DB) error {
+
+        // 文章作者从已有用户中随机分配
+        var userIDs []uint64
+        if err := db.Table("users").Pluck("id", &userIDs).Error; err != nil {
+            return err
+        }
+
+        // 分类同样随机分配，没有分类时文章为未分类
+        var categoryIDs []uint64
+        if err := db.Table("categories").Pluck("id", &categoryIDs).Error; err != nil {
+            return err
+        }
+
+        articles := factories.MakeArticles(50, userIDs, categoryIDs)
+        return db.CreateInBatches(&articles, 100).Error
+    })
+}
@@ -0,0 +1,29 @@
+package seeders
+
+import (
+	"goblog/app/models/category"
+	"goblog/pkg/seed"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 添加 Seeder
+    seed.Add("SeedCategoriesTable", func(db *gorm.DB) error {
+
+        categories := []category.Category{
+            {Name: "模板", Description: "Go 模板语法与页面渲染"},
+            {Name: "数据库", Description: "MySQL、SQLite 与 GORM 的使用"},
+            {Name: "路由", Description: "路由、中间件与请求处理"},
+            {Name: "部署", Description: "编译、配置与线上部署"},
+        }
+        // 按名称查找，已存在的分类不重复创建，seed 可以重复执行
+        for i := range categories {
+            if err := db.Where(category.Category{Name: categories[i].Name}).FirstOrCreate(&categories[i]).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
@@ -0,0 +1,51 @@
+package seeders
+
+import (
+	"goblog/app/models/comment"
+	"goblog/pkg/seed"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"gorm.io/gorm"
+)
+
+func init() {
+
+    // 添加 Seeder
+    seed.Add("SeedCommentsTable", func(db *gorm.DB) error {
+
+        var userIDs, articleIDs []uint64
+        if err := db.Table("users").Pluck("id", &userIDs).Error; err != nil {
+            return err
+        }
+        if err := db.Table("articles").Pluck("id", &articleIDs).Error; err != nil {
+            return err
+        }
+        if len(userIDs) == 0 {
+            return nil
+        }
+
+        // 每篇文章 0~4 条评论，约三分之一为对上一条评论的回复，少量待审核
+        for _, articleID := range articleIDs {
+            var parentID uint64
+            for n := gofakeit.Number(0, 4); n > 0; n-- {
+                _comment := comment.Comment{
+                    ArticleID: articleID,
+                    UserID:    userIDs[gofakeit.Number(0, len(userIDs)-1)],
+                    Body:      gofakeit.Sentence(gofakeit.Number(4, 16)),
+                    Status:    comment.StatusApproved,
+                }
+                if parentID > 0 && gofakeit.Number(1, 3) == 1 {
+                    _comment.ParentID = parentID
+                }
+                if gofakeit.Number(1, 10) == 1 {
+                    _comment.Status = comment.StatusPending
+                }
+                if err := db.Create(&_comment).Error; err != nil {
+                    return err
+                }
+                parentID = _comment.ID
+            }
+        }
+        return nil
+    })
+}
@@ -0,0 +1,15 @@
+// Package seeders 数据填充文件，每个文件在 init 中通过 seed.Add 注册
+package seeders
+
+import "goblog/pkg/seed"
+
+// Initialize 设置 Seeder 的执行顺序，被依赖的数据需要先填充
+func Initialize() {
+    seed.SetRunOrder([]string{
+        "SeedUsersTable",
+        "SeedCategoriesTable",
+        "SeedArticlesTable",
+        "SeedTagsTable",
+        "SeedCommentsTable",
+    })
+}
@@ -0,0 +1,53 @@
+package seeders
+
+import (
+	"goblog/app/models/tag"
+	"goblog/pkg/seed"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+
+    // 添加 Seeder
+    seed.Add("SeedTagsTable", func(db *gorm.DB) error {
+
+        names := []string{"Go", "GORM", "MySQL", "SQLite", "Markdown", "中间件", "路由", "模板", "测试", "性能", "部署", "Docker"}
+        // 按名称查找，已存在的标签不重复创建，seed 可以重复执行
+        tags := make([]tag.Tag, len(names))
+        for i, name := range names {
+            tags[i] = tag.Tag{Name: name, Slug: tag.MakeSlug(name)}
+            if err := db.Where(tag.Tag{Name: name}).FirstOrCreate(&tags[i]).Error; err != nil {
+                return err
+            }
+        }
+
+        var articleIDs []uint64
+        if err := db.Table("articles").Pluck("id", &articleIDs).Error; err != nil {
+            return err
+        }
+
+        // 每篇文章随机关联 0~3 个标签，靠前的标签更常用，使标签云有明显的大小区分
+        type ArticleTag struct {
+            ArticleID uint64
+            TagID     uint64
+        }
+        var rows []ArticleTag
+        for _, id := range articleIDs {
+            picked := make(map[uint64]bool)
+            for n := gofakeit.Number(0, 3); n > 0; n-- {
+                t := tags[gofakeit.Number(0, gofakeit.Number(0, len(tags)-1))]
+                if !picked[t.ID] {
+                    picked[t.ID] = true
+                    rows = append(rows, ArticleTag{ArticleID: id, TagID: t.ID})
+                }
+            }
+        }
+        if len(rows) == 0 {
+            return nil
+        }
+        return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, 100).Error
+    })
+}
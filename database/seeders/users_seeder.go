@@ -0,0 +1,57 @@
+package seeders
+
+import (
+	"goblog/app/models/role"
+	"goblog/app/models/user"
+	"goblog/database/factories"
+	"goblog/pkg/seed"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+
+    // 添加 Seeder
+    seed.Add("SeedUsersTable", func(db *gorm.DB) error {
+
+        // 固定的演示账号，方便登录体验
+        demo := factories.MakeUsers(1)[0]
+        demo.Name = "summer"
+        demo.Email = "summer@example.com"
+
+        // 演示账号已存在时沿用，重复执行 seed 不会因邮箱唯一约束失败
+        if err := db.Where(user.User{Email: demo.Email}).FirstOrCreate(&demo).Error; err != nil {
+            return err
+        }
+
+        // 通过模型创建，会触发 User 的 BeforeSave 钩子
+        users := factories.MakeUsers(9)
+        if err := db.Create(&users).Error; err != nil {
+            return err
+        }
+        users = append([]user.User{demo}, users...)
+
+        // 演示账号为管理员，第二个用户为编辑，其余为作者
+        var roles []role.Role
+        if err := db.Find(&roles).Error; err != nil {
+            return err
+        }
+        ids := map[string]uint64{}
+        for _, _role := range roles {
+            ids[_role.Name] = _role.ID
+        }
+        userRoles := make([]role.UserRole, len(users))
+        for i, _user := range users {
+            name := role.Author
+            switch i {
+            case 0:
+                name = role.Admin
+            case 1:
+                name = role.Editor
+            }
+            userRoles[i] = role.UserRole{UserID: _user.ID, RoleID: ids[name]}
+        }
+        return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&userRoles).Error
+    })
+}
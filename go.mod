@@ -3,16 +3,26 @@ module goblog
 go 1.15
 
 require (
+	github.com/brianvoe/gofakeit/v6 v6.5.0
+	github.com/chris-ramon/douceur v0.2.0 // indirect
 	github.com/go-sql-driver/mysql v1.6.0
+	github.com/gorilla/feeds v1.1.1
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/securecookie v1.1.1
 	github.com/gorilla/sessions v1.2.1
+	github.com/microcosm-cc/bluemonday v1.0.16
 	github.com/spf13/cast v1.3.1
+	github.com/spf13/cobra v1.1.3
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.7.0
 	github.com/thedevsaddam/govalidator v1.9.10
+	github.com/yuin/goldmark v1.3.7
+	go.uber.org/zap v1.17.0
 	golang.org/x/crypto v0.0.0-20210503195802-e9a32991a82e
-	golang.org/x/net v0.0.0-20210410081132-afb366fc7cd1
+	golang.org/x/net v0.0.0-20210614182718-04defd469f4e
+	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 	gorm.io/driver/mysql v1.0.5
+	gorm.io/driver/sqlite v1.1.4
 	gorm.io/gorm v1.21.7
 	honnef.co/go/tools v0.1.3
 )
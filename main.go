@@ -1,20 +1,45 @@
 package main
 
 import (
-	middwares "goblog/app/http/middlewares"
+	"fmt"
+	"goblog/app/cmd"
 	"goblog/bootstrap"
 	"goblog/config"
-	c "goblog/pkg/config"
-	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
 )
 
 func init() {
 	config.Initialize()
+	bootstrap.SetupLogger()
 }
 
 func main() {
-    bootstrap.SetUpDB()
-    router := bootstrap.SetupRoute()
+    rootCmd := &cobra.Command{
+        Use:   "goblog",
+        Short: "A simple blog written in Go",
+
+        // 不带子命令时启动 Web 服务
+        PreRun: cmd.CmdServe.PreRun,
+        Run:    cmd.CmdServe.Run,
+    }
 
-    http.ListenAndServe(":" + c.GetString("app.port"), middwares.RemoveTrailingSlash(router))
-}
\ No newline at end of file
+    rootCmd.AddCommand(
+        cmd.CmdServe,
+        cmd.CmdMigrate,
+        cmd.CmdMigrateRollback,
+        cmd.CmdMigrateStatus,
+        cmd.CmdMigrateFresh,
+        cmd.CmdSeed,
+        cmd.CmdKeyGenerate,
+        cmd.CmdUserCreate,
+        cmd.CmdUserRole,
+        cmd.CmdRouteList,
+    )
+
+    if err := rootCmd.Execute(); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
@@ -1,25 +1,54 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"goblog/app/models/user"
+	"goblog/pkg/logger"
 	"goblog/pkg/session"
+	"net/http"
 
 	"gorm.io/gorm"
 )
 
-func _getUID() string {
-    _uid := session.Get("uid")
+// contextKey 请求上下文中保存认证用户的键
+type contextKey struct{}
+
+// WithUser 将用户绑定到请求上下文，用于访问令牌等不依赖会话的认证方式，
+// 绑定后 User 和 Check 优先使用此用户
+func WithUser(r *http.Request, _user user.User) *http.Request {
+    return r.WithContext(context.WithValue(r.Context(), contextKey{}, _user))
+}
+
+func _contextUser(r *http.Request) (user.User, bool) {
+    _user, ok := r.Context().Value(contextKey{}).(user.User)
+    return _user, ok
+}
+
+// _getUID 获取会话中的用户 ID，会话中没有时尝试通过“记住我”的 Cookie 恢复登录
+func _getUID(r *http.Request) string {
+    _uid := session.Get(r, "uid")
     uid, ok := _uid.(string)
     if ok && len(uid) > 0 {
         return uid
     }
+    if session.FromRequest(r) == nil {
+        return ""
+    }
+    if _user, ok := userFromRememberCookie(r); ok {
+        Login(r, _user)
+        return _user.GetStringID()
+    }
     return ""
 }
 
 // User 获取登录用户信息
-func User() user.User {
-    uid := _getUID()
+func User(r *http.Request) user.User {
+    if _user, ok := _contextUser(r); ok {
+        return _user
+    }
+
+    uid := _getUID(r)
     if len(uid) > 0 {
         _user, err := user.Get(uid)
         if err == nil {
@@ -29,15 +58,31 @@ func User() user.User {
     return user.User{}
 }
 
-// Attempt 尝试登录
-func Attempt(email string, password string) error {
+// Attempt 尝试登录，remember 为 true 时同时写入“记住我”的 Cookie
+func Attempt(r *http.Request, email string, password string, remember bool) error {
+    // 0. 失败次数过多时需等待；先记录本次尝试再校验密码，并发的尝试也会被逐一计数。
+    //    存储出错时不阻止登录
+    if err := checkLoginThrottle(r, email); err != nil {
+        if _, ok := err.(*ThrottledError); ok {
+            return err
+        }
+        logger.LogError(err)
+    }
+    wait, err := hitLoginThrottle(r, email)
+    if err != nil {
+        if _, ok := err.(*ThrottledError); ok {
+            return err
+        }
+        logger.LogError(err)
+    }
+
     // 1. 根据 Email 获取用户
     _user, err := user.GetByEmail(email)
 
 	// 2. 如果出现错误
     if err != nil {
         if err == gorm.ErrRecordNotFound {
-            return errors.New("账号不存在或密码错误")
+            return loginFailure(wait, errors.New("账号不存在或密码错误"))
         } else {
             return errors.New("内部错误，请稍后尝试")
         }
@@ -45,26 +90,39 @@ func Attempt(email string, password string) error {
 
     // 3. 匹配密码
     if !_user.ComparePassword(password) {
-        return errors.New("账号不存在或密码错误")
+        return loginFailure(wait, errors.New("账号不存在或密码错误"))
     }
 
     // 4. 登录用户，保存会话
-    session.Put("uid", _user.GetStringID())
+    clearLoginThrottle(r, email)
+    Login(r, _user)
+    if remember {
+        rememberUser(r, _user)
+    }
 
     return nil
 }
 
-// Login 登录指定用户
-func Login(_user user.User) {
-    session.Put("uid", _user.GetStringID())
+// Login 登录指定用户，登录前更换会话 ID 以防止会话固定攻击
+func Login(r *http.Request, _user user.User) {
+    session.Regenerate(r)
+    session.Put(r, "uid", _user.GetStringID())
 }
 
 // Logout 退出用户
-func Logout() {
-    session.Forget("uid")
+func Logout(r *http.Request) {
+    // 重新生成密钥，使该用户在所有设备上的“记住我”失效
+    if _user := User(r); _user.ID > 0 {
+        _user.CycleRememberToken()
+    }
+    session.Forget(r, "uid")
+    forgetRemembered(r)
 }
 
 // Check 检测是否登录
-func Check() bool {
-    return len(_getUID()) > 0
-}
\ No newline at end of file
+func Check(r *http.Request) bool {
+    if _, ok := _contextUser(r); ok {
+        return true
+    }
+    return len(_getUID(r)) > 0
+}
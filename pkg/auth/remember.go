@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"goblog/app/models/user"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"goblog/pkg/session"
+	"net/http"
+	"strings"
+)
+
+// rememberUser 为用户写入“记住我”的 Cookie，用户还没有密钥时先生成
+func rememberUser(r *http.Request, _user user.User) {
+    if _user.RememberToken == "" {
+        if err := _user.CycleRememberToken(); err != nil {
+            return
+        }
+    }
+    setRememberCookie(r, _user.GetStringID()+"|"+rememberSignature(_user), config.GetInt("session.remember_lifetime", 43200)*60)
+}
+
+// forgetRemembered 删除“记住我”的 Cookie
+func forgetRemembered(r *http.Request) {
+    setRememberCookie(r, "", -1)
+}
+
+// userFromRememberCookie 通过“记住我”的 Cookie 获取用户，Cookie 无效时将其删除
+func userFromRememberCookie(r *http.Request) (user.User, bool) {
+    cookie, err := r.Cookie(config.GetString("session.remember_name", "goblog-remember"))
+    if err != nil || cookie.Value == "" {
+        return user.User{}, false
+    }
+
+    parts := strings.SplitN(cookie.Value, "|", 2)
+    if len(parts) == 2 {
+        _user, err := user.Get(parts[0])
+        if err == nil && _user.RememberToken != "" &&
+            hmac.Equal([]byte(parts[1]), []byte(rememberSignature(_user))) {
+            return _user, true
+        }
+    }
+
+    forgetRemembered(r)
+    return user.User{}, false
+}
+
+// rememberSignature 以 app.key 对用户 ID 和密钥签名，数据库泄露时无法伪造 Cookie
+func rememberSignature(_user user.User) string {
+    mac := hmac.New(sha256.New, []byte(config.GetString("app.key")))
+    mac.Write([]byte(_user.GetStringID() + "|" + _user.RememberToken))
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// setRememberCookie 写入 Cookie，maxAge 小于 0 时删除
+func setRememberCookie(r *http.Request, value string, maxAge int) {
+    s := session.FromRequest(r)
+    if s == nil {
+        logger.Warn("未开启会话，无法写入“记住我”的 Cookie")
+        return
+    }
+    http.SetCookie(s.Response, &http.Cookie{
+        Name:     config.GetString("session.remember_name", "goblog-remember"),
+        Value:    value,
+        Path:     "/",
+        MaxAge:   maxAge,
+        HttpOnly: true,
+    })
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"fmt"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"goblog/pkg/throttle"
+	"goblog/pkg/realip"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ThrottledError 登录失败次数过多，需等待 Wait 后重试
+type ThrottledError struct {
+    Wait time.Duration
+}
+
+// Error 提示需等待的秒数，不足一秒按一秒计
+func (e *ThrottledError) Error() string {
+    return fmt.Sprintf("登录失败次数过多，请在 %d 秒后重试", int(math.Ceil(e.Wait.Seconds())))
+}
+
+// loginLimiters 按 Email 和按 IP 计数的登录限制器
+func loginLimiters() (byEmail *throttle.Limiter, byIP *throttle.Limiter) {
+    lockout := time.Duration(config.GetInt("throttle.login.lockout", 900)) * time.Second
+    byEmail = &throttle.Limiter{
+        Store:       throttle.DefaultStore(),
+        MaxAttempts: config.GetInt("throttle.login.max_attempts", 5),
+        Backoff:     time.Duration(config.GetInt("throttle.login.backoff", 1)) * time.Second,
+        Lockout:     lockout,
+    }
+    byIP = &throttle.Limiter{
+        Store:       throttle.DefaultStore(),
+        MaxAttempts: config.GetInt("throttle.login.max_attempts_per_ip", 20),
+        Lockout:     lockout,
+    }
+    return
+}
+
+// loginKeys 登录限制的计数键
+func loginKeys(r *http.Request, email string) (emailKey string, ipKey string) {
+    return "login:email:" + strings.ToLower(strings.TrimSpace(email)), "login:ip:" + realip.FromRequest(r)
+}
+
+// checkLoginThrottle 检查是否需要等待后才能再次尝试登录
+func checkLoginThrottle(r *http.Request, email string) error {
+    byEmail, byIP := loginLimiters()
+    emailKey, ipKey := loginKeys(r, email)
+
+    wait, err := byEmail.AvailableIn(emailKey)
+    if err != nil {
+        return err
+    }
+    ipWait, err := byIP.AvailableIn(ipKey)
+    if err != nil {
+        return err
+    }
+    if ipWait > wait {
+        wait = ipWait
+    }
+    if wait > 0 {
+        return &ThrottledError{Wait: wait}
+    }
+    return nil
+}
+
+// hitLoginThrottle 在校验密码之前记录一次尝试，超过次数上限时返回 ThrottledError，
+// 否则返回本次尝试失败后需等待的时间。按 IP 的计数包含成功的登录
+func hitLoginThrottle(r *http.Request, email string) (time.Duration, error) {
+    byEmail, byIP := loginLimiters()
+    emailKey, ipKey := loginKeys(r, email)
+
+    attempts, wait, err := byEmail.Hit(emailKey)
+    if err != nil {
+        return 0, err
+    }
+    ipAttempts, ipWait, err := byIP.Hit(ipKey)
+    if err != nil {
+        return 0, err
+    }
+    if ipWait > wait {
+        wait = ipWait
+    }
+
+    if byEmail.TooMany(attempts) || byIP.TooMany(ipAttempts) {
+        return wait, &ThrottledError{Wait: wait}
+    }
+    return wait, nil
+}
+
+// loginFailure 登录失败，本次失败导致锁定时返回 ThrottledError，否则返回 failure。
+// 退避的等待较短，下次尝试时再提示
+func loginFailure(wait time.Duration, failure error) error {
+    byEmail, _ := loginLimiters()
+    if wait >= byEmail.Lockout {
+        return &ThrottledError{Wait: wait}
+    }
+    return failure
+}
+
+// clearLoginThrottle 登录成功后清除该 Email 的失败记录，IP 的记录保留，
+// 避免攻击者用自己的账号登录来重置计数
+func clearLoginThrottle(r *http.Request, email string) {
+    byEmail, _ := loginLimiters()
+    emailKey, _ := loginKeys(r, email)
+    logger.LogError(byEmail.Clear(emailKey))
+}
@@ -25,9 +25,11 @@ func init() {
     // 4. 环境变量配置文件查找的路径，相对于 main.go
     Viper.AddConfigPath(".")
 
-    // 5. 开始读根目录下的 .env 文件，读不到会报错
+    // 5. 开始读根目录下的 .env 文件，文件不存在时（如在子目录中运行测试）使用环境变量和默认值
     err := Viper.ReadInConfig()
-    logger.LogError(err)
+    if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+        logger.LogError(err)
+    }
 
     // 6. 设置环境变量前缀，用以区分 Go 的系统环境变量
     Viper.SetEnvPrefix("appenv")
@@ -0,0 +1,40 @@
+// Package console 命令行输出辅助方法
+package console
+
+import (
+	"fmt"
+	"os"
+)
+
+// Success 打印一条成功消息，绿色输出
+func Success(msg string) {
+    colorOut(msg, "32")
+}
+
+// Error 打印一条报错消息，红色输出
+func Error(msg string) {
+    colorOut(msg, "31")
+}
+
+// Warning 打印一条提示消息，黄色输出
+func Warning(msg string) {
+    colorOut(msg, "33")
+}
+
+// Exit 打印一条报错消息，并退出 os.Exit(1)
+func Exit(msg string) {
+    Error(msg)
+    os.Exit(1)
+}
+
+// ExitIf 语法糖，自带 err != nil 判断
+func ExitIf(err error) {
+    if err != nil {
+        Exit(err.Error())
+    }
+}
+
+// colorOut 内部使用，设置高亮颜色
+func colorOut(message, color string) {
+    fmt.Fprintf(os.Stdout, "\x1b[%sm%s\x1b[0m\n", color, message)
+}
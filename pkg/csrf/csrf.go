@@ -0,0 +1,60 @@
+// Package csrf 跨站请求伪造防护，令牌保存在会话中，每个会话一个
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"goblog/pkg/logger"
+	"goblog/pkg/session"
+	"html/template"
+	"net/http"
+)
+
+const (
+    // FieldName 表单中提交令牌的字段名
+    FieldName = "_token"
+
+    // HeaderName 以 AJAX 或 API 客户端提交时使用的请求标头
+    HeaderName = "X-CSRF-Token"
+
+    // sessionKey 令牌在会话中的键名
+    sessionKey = "_csrf_token"
+)
+
+// Token 获取当前会话的令牌，不存在时生成并写入会话。
+// 写入会话需要设置 Cookie，首次调用需在输出响应内容之前
+func Token(r *http.Request) string {
+    if token, ok := session.Get(r, sessionKey).(string); ok && len(token) > 0 {
+        return token
+    }
+
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        logger.LogError(err)
+        return ""
+    }
+    token := base64.RawURLEncoding.EncodeToString(b)
+    session.Put(r, sessionKey, token)
+    return token
+}
+
+// Field 包含令牌的隐藏表单字段
+func Field(token string) template.HTML {
+    return template.HTML(`<input type="hidden" name="` + FieldName + `" value="` +
+        template.HTMLEscapeString(token) + `">`)
+}
+
+// Verify 校验请求提交的令牌，优先读取表单字段，其次读取请求标头
+func Verify(r *http.Request) bool {
+    expected, _ := session.Get(r, sessionKey).(string)
+    if len(expected) == 0 {
+        return false
+    }
+
+    submitted := r.PostFormValue(FieldName)
+    if len(submitted) == 0 {
+        submitted = r.Header.Get(HeaderName)
+    }
+    return subtle.ConstantTimeCompare([]byte(submitted), []byte(expected)) == 1
+}
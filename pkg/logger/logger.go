@@ -1,9 +1,153 @@
+// Package logger 分级日志，基于 zap，支持 JSON / 文本格式输出到终端或按大小切割的文件
 package logger
 
-import "log"
+import (
+	"context"
+	"os"
+	"strings"
 
-func LogError(err error) {
-	if err != nil {
-		log.Fatal(err)
-	}
-}
\ No newline at end of file
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Logger 全局 Logger 对象，InitLogger 调用之前输出文本格式到终端
+var Logger = newLogger(zapcore.AddSync(os.Stdout), "text", zapcore.DebugLevel)
+
+// Options 日志配置，由 bootstrap.SetupLogger 从 config 中读取
+type Options struct {
+    // Level 日志级别：debug、info、warn、error
+    Level string
+    // Format 输出格式：json 或 text
+    Format string
+    // Channel 输出位置：stdout 或 file
+    Channel string
+
+    // 以下为 file 通道的配置
+    Filename  string
+    MaxSize   int  // 每个日志文件的最大尺寸，单位 MB
+    MaxBackup int  // 最多保存的日志文件数，0 为不限
+    MaxAge    int  // 最多保存的天数，0 为不删除
+    Compress  bool // 是否压缩旧日志文件
+}
+
+// InitLogger 初始化日志
+func InitLogger(opts Options) {
+    var writeSyncer zapcore.WriteSyncer
+    if opts.Channel == "file" {
+        writeSyncer = zapcore.AddSync(&lumberjack.Logger{
+            Filename:   opts.Filename,
+            MaxSize:    opts.MaxSize,
+            MaxBackups: opts.MaxBackup,
+            MaxAge:     opts.MaxAge,
+            Compress:   opts.Compress,
+        })
+    } else {
+        writeSyncer = zapcore.AddSync(os.Stdout)
+    }
+
+    Logger = newLogger(writeSyncer, opts.Format, parseLevel(opts.Level))
+
+    // 将自定义的 logger 替换为全局的 logger，zap.L() 调用时会使用此 logger
+    zap.ReplaceGlobals(Logger)
+}
+
+func newLogger(writeSyncer zapcore.WriteSyncer, format string, level zapcore.Level) *zap.Logger {
+    encoderConfig := zapcore.EncoderConfig{
+        TimeKey:        "time",
+        LevelKey:       "level",
+        NameKey:        "logger",
+        CallerKey:      "caller",
+        FunctionKey:    zapcore.OmitKey,
+        MessageKey:     "message",
+        StacktraceKey:  "stacktrace",
+        LineEnding:     zapcore.DefaultLineEnding,
+        EncodeLevel:    zapcore.CapitalLevelEncoder,
+        EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02 15:04:05"),
+        EncodeDuration: zapcore.StringDurationEncoder,
+        EncodeCaller:   zapcore.ShortCallerEncoder,
+    }
+
+    var encoder zapcore.Encoder
+    if format == "json" {
+        encoder = zapcore.NewJSONEncoder(encoderConfig)
+    } else {
+        encoder = zapcore.NewConsoleEncoder(encoderConfig)
+    }
+
+    core := zapcore.NewCore(encoder, writeSyncer, level)
+
+    // AddCallerSkip(1) 使日志中的调用位置指向调用本包方法的代码
+    return zap.New(core,
+        zap.AddCaller(),
+        zap.AddCallerSkip(1),
+        zap.AddStacktrace(zapcore.ErrorLevel),
+    )
+}
+
+// parseLevel 解析日志级别，无法识别时使用 info
+func parseLevel(level string) zapcore.Level {
+    var l zapcore.Level
+    if err := l.UnmarshalText([]byte(strings.ToLower(level))); err != nil {
+        return zapcore.InfoLevel
+    }
+    return l
+}
+
+// Debug 调试日志，详尽的程序日志
+func Debug(message string, fields ...zap.Field) {
+    Logger.Debug(message, fields...)
+}
+
+// Info 告知类日志
+func Info(message string, fields ...zap.Field) {
+    Logger.Info(message, fields...)
+}
+
+// Warn 警告类
+func Warn(message string, fields ...zap.Field) {
+    Logger.Warn(message, fields...)
+}
+
+// Error 错误时记录，程序继续运行
+func Error(message string, fields ...zap.Field) {
+    Logger.Error(message, fields...)
+}
+
+// Fatal 无法恢复的错误，写入日志后调用 os.Exit(1)，只应在启动阶段使用
+func Fatal(message string, fields ...zap.Field) {
+    Logger.Fatal(message, fields...)
+}
+
+// LogError 当 err != nil 时记录 error 等级的日志，不会中断程序
+func LogError(err error, fields ...zap.Field) {
+    if err != nil {
+        Logger.Error(err.Error(), append(fields, zap.Error(err))...)
+    }
+}
+
+// LogWarn 当 err != nil 时记录 warn 等级的日志，用于可预期的错误
+func LogWarn(err error, fields ...zap.Field) {
+    if err != nil {
+        Logger.Warn(err.Error(), append(fields, zap.Error(err))...)
+    }
+}
+
+// contextKey 上下文字段在 Context 中的键名
+type contextKey struct{}
+
+// NewContext 为 Context 附加日志字段，如 request_id、user_id，后续通过 WithContext 取出
+func NewContext(ctx context.Context, fields ...zap.Field) context.Context {
+    existing, _ := ctx.Value(contextKey{}).([]zap.Field)
+    merged := make([]zap.Field, 0, len(existing)+len(fields))
+    merged = append(merged, existing...)
+    merged = append(merged, fields...)
+    return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// WithContext 返回携带 Context 中日志字段的 Logger，如 logger.WithContext(r.Context()).Info(...)
+func WithContext(ctx context.Context) *zap.Logger {
+    fields, _ := ctx.Value(contextKey{}).([]zap.Field)
+    // 直接使用返回的 Logger 时不经过本包的方法，需抵消 AddCallerSkip(1)
+    return Logger.WithOptions(zap.AddCallerSkip(-1)).With(fields...)
+}
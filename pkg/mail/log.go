@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"goblog/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer 将邮件写入日志而不真正发送，用于本地开发
+type LogMailer struct {
+}
+
+// NewLogMailer 创建 log 驱动
+func NewLogMailer() *LogMailer {
+    return &LogMailer{}
+}
+
+// Send 记录邮件内容
+func (*LogMailer) Send(msg Message) error {
+    to := make([]string, len(msg.To))
+    for i, addr := range msg.To {
+        to[i] = addr.Address
+    }
+    body := msg.Text
+    if body == "" {
+        body = msg.HTML
+    }
+
+    logger.Info("发送邮件",
+        zap.String("from", msg.From.Address),
+        zap.Strings("to", to),
+        zap.String("subject", msg.Subject),
+        zap.String("body", body),
+    )
+    return nil
+}
@@ -0,0 +1,92 @@
+// Package mail 发送邮件，支持 smtp、log（写入日志）和 memory（保存在内存中，用于测试）驱动
+package mail
+
+import (
+	"fmt"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"sync"
+)
+
+// Address 邮件地址，Name 为显示名称
+type Address struct {
+    Address string
+    Name    string
+}
+
+// String 格式化为 "Name <address>"，没有名称时只返回地址
+func (a Address) String() string {
+    if a.Name == "" {
+        return a.Address
+    }
+    return fmt.Sprintf("%s <%s>", mimeWord(a.Name), a.Address)
+}
+
+// Message 邮件内容，Text 和 HTML 至少提供一项，From 为空时使用 mail.from 配置
+type Message struct {
+    From    Address
+    To      []Address
+    Subject string
+    Text    string
+    HTML    string
+}
+
+// Mailer 邮件驱动
+type Mailer interface {
+    Send(msg Message) error
+}
+
+var (
+    mailer     Mailer
+    mailerOnce sync.Once
+)
+
+// NewMailer 根据驱动名称创建邮件驱动
+func NewMailer(name string) (Mailer, error) {
+    switch name {
+    case "smtp":
+        return NewSMTPMailer(), nil
+    case "log":
+        return NewLogMailer(), nil
+    case "memory":
+        return NewMemoryMailer(), nil
+    }
+    return nil, fmt.Errorf("mail: 不支持的邮件驱动 %q", name)
+}
+
+// Default 获取邮件驱动，首次使用时根据 mail.default 配置创建
+func Default() Mailer {
+    mailerOnce.Do(func() {
+        if mailer != nil {
+            return
+        }
+        m, err := NewMailer(config.GetString("mail.default"))
+        if err != nil {
+            // 驱动配置错误时退回 log 驱动，避免邮件静默丢失
+            logger.LogError(err)
+            m = NewLogMailer()
+        }
+        mailer = m
+    })
+    return mailer
+}
+
+// SetMailer 指定邮件驱动，用于测试
+func SetMailer(m Mailer) {
+    mailerOnce.Do(func() {})
+    mailer = m
+}
+
+// Send 使用默认驱动发送邮件
+func Send(msg Message) error {
+    if msg.From.Address == "" {
+        msg.From = Address{
+            Address: config.GetString("mail.from.address"),
+            Name:    config.GetString("mail.from.name"),
+        }
+    }
+    if len(msg.To) == 0 {
+        return fmt.Errorf("mail: 邮件 %q 没有收件人", msg.Subject)
+    }
+    return Default().Send(msg)
+}
@@ -0,0 +1,46 @@
+package mail
+
+import "sync"
+
+// MemoryMailer 将邮件保存在内存中，用于测试
+type MemoryMailer struct {
+    mu       sync.Mutex
+    messages []Message
+}
+
+// NewMemoryMailer 创建 memory 驱动
+func NewMemoryMailer() *MemoryMailer {
+    return &MemoryMailer{}
+}
+
+// Send 保存邮件
+func (m *MemoryMailer) Send(msg Message) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.messages = append(m.messages, msg)
+    return nil
+}
+
+// Messages 已发送的全部邮件
+func (m *MemoryMailer) Messages() []Message {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    return append([]Message(nil), m.messages...)
+}
+
+// Last 最后发送的邮件，没有邮件时 ok 为 false
+func (m *MemoryMailer) Last() (msg Message, ok bool) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    if len(m.messages) == 0 {
+        return Message{}, false
+    }
+    return m.messages[len(m.messages)-1], true
+}
+
+// Reset 清空已发送的邮件
+func (m *MemoryMailer) Reset() {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.messages = nil
+}
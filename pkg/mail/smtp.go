@@ -0,0 +1,102 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"goblog/pkg/config"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPMailer 通过 SMTP 服务器发送邮件，服务器支持时自动使用 STARTTLS
+type SMTPMailer struct {
+    Host     string
+    Port     int
+    Username string
+    Password string
+}
+
+// NewSMTPMailer 使用 mail.smtp 配置创建 SMTP 驱动
+func NewSMTPMailer() *SMTPMailer {
+    return &SMTPMailer{
+        Host:     config.GetString("mail.smtp.host"),
+        Port:     config.GetInt("mail.smtp.port"),
+        Username: config.GetString("mail.smtp.username"),
+        Password: config.GetString("mail.smtp.password"),
+    }
+}
+
+// Send 发送邮件
+func (m *SMTPMailer) Send(msg Message) error {
+    var auth smtp.Auth
+    if m.Username != "" {
+        auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+    }
+
+    to := make([]string, len(msg.To))
+    for i, addr := range msg.To {
+        to[i] = addr.Address
+    }
+
+    addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+    if err := smtp.SendMail(addr, auth, msg.From.Address, to, buildMIME(msg)); err != nil {
+        return fmt.Errorf("mail: 通过 %s 发送邮件失败：%w", addr, err)
+    }
+    return nil
+}
+
+// buildMIME 生成邮件原文，同时有 Text 和 HTML 时使用 multipart/alternative
+func buildMIME(msg Message) []byte {
+    var buf bytes.Buffer
+
+    to := make([]string, len(msg.To))
+    for i, addr := range msg.To {
+        to[i] = addr.String()
+    }
+    fmt.Fprintf(&buf, "From: %s\r\n", msg.From)
+    fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+    fmt.Fprintf(&buf, "Subject: %s\r\n", mimeWord(msg.Subject))
+    fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+    buf.WriteString("MIME-Version: 1.0\r\n")
+
+    switch {
+    case msg.Text != "" && msg.HTML != "":
+        boundary := randomBoundary()
+        fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+        writePart(&buf, boundary, "text/plain", msg.Text)
+        writePart(&buf, boundary, "text/html", msg.HTML)
+        fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+    case msg.HTML != "":
+        buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+        buf.WriteString(msg.HTML)
+    default:
+        buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+        buf.WriteString(msg.Text)
+    }
+
+    return buf.Bytes()
+}
+
+func writePart(buf *bytes.Buffer, boundary string, contentType string, body string) {
+    fmt.Fprintf(buf, "--%s\r\n", boundary)
+    fmt.Fprintf(buf, "Content-Type: %s; charset=UTF-8\r\n\r\n", contentType)
+    buf.WriteString(body)
+    buf.WriteString("\r\n")
+}
+
+func randomBoundary() string {
+    b := make([]byte, 16)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
+
+// mimeWord 编码标头中的非 ASCII 字符，如中文标题和发件人名称
+func mimeWord(s string) string {
+    return mime.BEncoding.Encode("UTF-8", s)
+}
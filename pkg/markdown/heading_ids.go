@@ -0,0 +1,53 @@
+package markdown
+
+import (
+	"bytes"
+	"strconv"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// headingIDs 生成标题锚点 ID，与 goldmark 默认实现不同的是保留中文等非 ASCII 字符，
+// 如「数据库 设计」生成 数据库-设计，重复的 ID 追加 -1、-2 后缀
+type headingIDs struct {
+    values map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+    return &headingIDs{values: make(map[string]bool)}
+}
+
+// Generate 实现 parser.IDs 接口
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+    var buf bytes.Buffer
+    lastDash := false
+    for _, r := range string(bytes.TrimSpace(value)) {
+        switch {
+        case unicode.IsLetter(r) || unicode.IsNumber(r):
+            buf.WriteRune(unicode.ToLower(r))
+            lastDash = false
+        case r == '-' || r == '_' || unicode.IsSpace(r):
+            if !lastDash && buf.Len() > 0 {
+                buf.WriteByte('-')
+                lastDash = true
+            }
+        }
+    }
+    id := string(bytes.TrimRight(buf.Bytes(), "-"))
+    if len(id) == 0 {
+        id = "heading"
+    }
+
+    result := id
+    for i := 1; s.values[result]; i++ {
+        result = id + "-" + strconv.Itoa(i)
+    }
+    s.values[result] = true
+    return []byte(result)
+}
+
+// Put 实现 parser.IDs 接口，记录手动指定的 ID
+func (s *headingIDs) Put(value []byte) {
+    s.values[string(value)] = true
+}
@@ -0,0 +1,113 @@
+// Package markdown 将 Markdown 渲染为经过安全过滤的 HTML
+package markdown
+
+import (
+	"bytes"
+	"goblog/pkg/logger"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+)
+
+// md 支持 GFM（表格、删除线、任务列表、自动链接）、脚注，并为标题生成锚点 ID
+var md = goldmark.New(
+    goldmark.WithExtensions(
+        extension.GFM,
+        extension.Footnote,
+    ),
+    goldmark.WithParserOptions(
+        parser.WithAutoHeadingID(),
+    ),
+)
+
+// policy 在 UGC 策略的基础上，放行标题锚点、脚注和代码高亮需要的属性
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+    p := bluemonday.UGCPolicy()
+
+    // 标题锚点与脚注的 ID
+    p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_:\-]+$`)).
+        OnElements("h1", "h2", "h3", "h4", "h5", "h6", "li", "sup")
+    // 代码块的语言，如 language-go；脚注的样式
+    p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+\-]+$`)).OnElements("code")
+    p.AllowAttrs("class").Matching(regexp.MustCompile(`^footnote(s|-ref|-backref)?$`)).
+        OnElements("a", "div", "sup")
+    p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(noteref|backlink|endnotes)$`)).
+        OnElements("a", "div", "section")
+    // 任务列表的复选框
+    p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+    p.AllowAttrs("checked", "disabled").OnElements("input")
+
+    return p
+}
+
+// Render 渲染 Markdown，返回过滤后的安全 HTML
+func Render(source string) template.HTML {
+    var buf bytes.Buffer
+    ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+    if err := md.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+        logger.LogError(err)
+        return template.HTML(template.HTMLEscapeString(source))
+    }
+    return template.HTML(policy.SanitizeBytes(buf.Bytes()))
+}
+
+// stripPolicy 去掉所有 HTML 标签，用于生成纯文本
+var stripPolicy = bluemonday.StrictPolicy()
+
+// PlainText 将 Markdown 转换为去掉格式的纯文本，连续空白合并为一个空格，用于搜索摘要等场景
+func PlainText(source string) string {
+    var buf bytes.Buffer
+    if err := md.Convert([]byte(source), &buf); err != nil {
+        logger.LogError(err)
+        return strings.Join(strings.Fields(source), " ")
+    }
+    // 块级元素之间补充空白，避免相邻段落的文字连在一起
+    text := stripPolicy.Sanitize(strings.Replace(buf.String(), ">\n<", "> <", -1))
+    return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+// cacheEntry 某个 key 最近一次渲染的结果
+type cacheEntry struct {
+    revision string
+    html     template.HTML
+}
+
+var (
+    cache   = make(map[string]cacheEntry)
+    cacheMu sync.RWMutex
+)
+
+// RenderCached 渲染并按 key 缓存，revision 不同时（如文章已更新）重新渲染并覆盖旧的结果，
+// 每个 key 只保留最新版本
+func RenderCached(key string, revision string, source string) template.HTML {
+    cacheMu.RLock()
+    entry, ok := cache[key]
+    cacheMu.RUnlock()
+    if ok && entry.revision == revision {
+        return entry.html
+    }
+
+    html := Render(source)
+
+    cacheMu.Lock()
+    cache[key] = cacheEntry{revision: revision, html: html}
+    cacheMu.Unlock()
+
+    return html
+}
+
+// Forget 删除 key 的缓存，如文章被删除时
+func Forget(key string) {
+    cacheMu.Lock()
+    delete(cache, key)
+    cacheMu.Unlock()
+}
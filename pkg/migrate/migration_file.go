@@ -0,0 +1,51 @@
+// Package migrate 带版本的数据库迁移，记录在 migrations 数据表中
+package migrate
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// MigrationFunc 迁移执行的方法，可通过 db.Migrator() 操作表结构，或使用 db.Exec 执行原生 SQL
+type MigrationFunc func(db *gorm.DB) error
+
+// MigrationFile 单个迁移文件
+type MigrationFile struct {
+    Up       MigrationFunc
+    Down     MigrationFunc
+    FileName string
+}
+
+// migrationFiles 所有注册的迁移文件
+var migrationFiles []MigrationFile
+
+// Add 新增一个迁移文件，由 database/migrations 下的迁移文件在 init 中调用
+// name 以时间戳开头，如 2021_05_20_000001_create_users_table，执行时按名称排序
+func Add(name string, up MigrationFunc, down MigrationFunc) {
+    migrationFiles = append(migrationFiles, MigrationFile{
+        FileName: name,
+        Up:       up,
+        Down:     down,
+    })
+}
+
+// getMigrationFiles 按名称排序后的全部迁移文件
+func getMigrationFiles() []MigrationFile {
+    files := make([]MigrationFile, len(migrationFiles))
+    copy(files, migrationFiles)
+    sort.Slice(files, func(i, j int) bool {
+        return files[i].FileName < files[j].FileName
+    })
+    return files
+}
+
+// getMigrationFile 通过名称获取迁移文件
+func getMigrationFile(name string) (MigrationFile, bool) {
+    for _, mfile := range migrationFiles {
+        if mfile.FileName == name {
+            return mfile, true
+        }
+    }
+    return MigrationFile{}, false
+}
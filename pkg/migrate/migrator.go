@@ -0,0 +1,180 @@
+package migrate
+
+import (
+	"fmt"
+	"goblog/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// Migration 对应 migrations 表里的一条记录
+type Migration struct {
+    ID        uint64 `gorm:"primaryKey;autoIncrement;"`
+    Migration string `gorm:"type:varchar(191);not null;unique;"`
+    Batch     int
+}
+
+// Status 迁移文件的执行状态
+type Status struct {
+    Migration string
+    Ran       bool
+    Batch     int
+}
+
+// Migrator 数据迁移操作类
+type Migrator struct {
+    DB *gorm.DB
+}
+
+// NewMigrator 创建 Migrator 实例，用以执行迁移操作，migrations 表不存在时自动创建
+func NewMigrator() (*Migrator, error) {
+    migrator := &Migrator{
+        DB: model.DB,
+    }
+    if err := migrator.createMigrationsTable(); err != nil {
+        return nil, err
+    }
+    return migrator, nil
+}
+
+// createMigrationsTable 创建 migrations 表
+func (migrator *Migrator) createMigrationsTable() error {
+    if !migrator.DB.Migrator().HasTable(&Migration{}) {
+        return migrator.DB.Migrator().CreateTable(&Migration{})
+    }
+    return nil
+}
+
+// Up 执行所有未迁移过的文件，返回本次执行的迁移名称
+func (migrator *Migrator) Up() ([]string, error) {
+    ran, err := migrator.ranMigrations()
+    if err != nil {
+        return nil, err
+    }
+
+    // 本次迁移属于同一批次，回滚时一起回滚
+    batch := migrator.getBatch()
+
+    var migrated []string
+    for _, mfile := range getMigrationFiles() {
+        if _, ok := ran[mfile.FileName]; ok {
+            continue
+        }
+        if err := migrator.runUpMigration(mfile, batch); err != nil {
+            return migrated, err
+        }
+        migrated = append(migrated, mfile.FileName)
+    }
+    return migrated, nil
+}
+
+// Rollback 回滚上一个批次的迁移，返回被回滚的迁移名称
+func (migrator *Migrator) Rollback() ([]string, error) {
+    var lastMigration Migration
+    if err := migrator.DB.Order("id DESC").Limit(1).Find(&lastMigration).Error; err != nil {
+        return nil, err
+    }
+    if lastMigration.ID == 0 {
+        return nil, nil
+    }
+
+    var migrations []Migration
+    if err := migrator.DB.Where("batch = ?", lastMigration.Batch).
+        Order("id DESC").Find(&migrations).Error; err != nil {
+        return nil, err
+    }
+    return migrator.rollbackMigrations(migrations)
+}
+
+// Reset 回滚所有迁移
+func (migrator *Migrator) Reset() ([]string, error) {
+    var migrations []Migration
+    if err := migrator.DB.Order("id DESC").Find(&migrations).Error; err != nil {
+        return nil, err
+    }
+    return migrator.rollbackMigrations(migrations)
+}
+
+// Fresh 删除所有数据表，并重新执行所有迁移
+func (migrator *Migrator) Fresh() ([]string, error) {
+    if err := dropAllTables(migrator.DB); err != nil {
+        return nil, err
+    }
+    migrator.createMigrationsTable()
+    return migrator.Up()
+}
+
+// Status 所有迁移文件的执行状态
+func (migrator *Migrator) Status() ([]Status, error) {
+    ran, err := migrator.ranMigrations()
+    if err != nil {
+        return nil, err
+    }
+
+    var statuses []Status
+    for _, mfile := range getMigrationFiles() {
+        m, ok := ran[mfile.FileName]
+        statuses = append(statuses, Status{
+            Migration: mfile.FileName,
+            Ran:       ok,
+            Batch:     m.Batch,
+        })
+    }
+    return statuses, nil
+}
+
+// ranMigrations 已执行过的迁移，以名称为键
+func (migrator *Migrator) ranMigrations() (map[string]Migration, error) {
+    var migrations []Migration
+    if err := migrator.DB.Find(&migrations).Error; err != nil {
+        return nil, err
+    }
+    ran := make(map[string]Migration, len(migrations))
+    for _, m := range migrations {
+        ran[m.Migration] = m
+    }
+    return ran, nil
+}
+
+// getBatch 获取当前这个批次的值
+func (migrator *Migrator) getBatch() int {
+    batch := 1
+
+    lastMigration := Migration{}
+    migrator.DB.Order("id DESC").Limit(1).Find(&lastMigration)
+    if lastMigration.ID > 0 {
+        batch = lastMigration.Batch + 1
+    }
+    return batch
+}
+
+// runUpMigration 执行迁移的 up 方法，成功后写入 migrations 表
+func (migrator *Migrator) runUpMigration(mfile MigrationFile, batch int) error {
+    if mfile.Up != nil {
+        if err := mfile.Up(migrator.DB); err != nil {
+            return fmt.Errorf("migrate: 执行 %s 失败：%w", mfile.FileName, err)
+        }
+    }
+    return migrator.DB.Create(&Migration{Migration: mfile.FileName, Batch: batch}).Error
+}
+
+// rollbackMigrations 依次回滚迁移，执行 down 方法后删除 migrations 表中的记录
+func (migrator *Migrator) rollbackMigrations(migrations []Migration) ([]string, error) {
+    var rolledBack []string
+    for _, m := range migrations {
+        mfile, ok := getMigrationFile(m.Migration)
+        if !ok {
+            return rolledBack, fmt.Errorf("migrate: 找不到迁移文件 %s", m.Migration)
+        }
+        if mfile.Down != nil {
+            if err := mfile.Down(migrator.DB); err != nil {
+                return rolledBack, fmt.Errorf("migrate: 回滚 %s 失败：%w", m.Migration, err)
+            }
+        }
+        if err := migrator.DB.Delete(&m).Error; err != nil {
+            return rolledBack, err
+        }
+        rolledBack = append(rolledBack, m.Migration)
+    }
+    return rolledBack, nil
+}
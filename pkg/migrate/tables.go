@@ -0,0 +1,47 @@
+package migrate
+
+import (
+	"goblog/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// tableNames 当前数据库中所有的数据表
+func tableNames(db *gorm.DB) ([]string, error) {
+    var tables []string
+    var err error
+
+    switch model.Connection() {
+    case "sqlite":
+        err = db.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'").
+            Scan(&tables).Error
+    default:
+        err = db.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = ?",
+            db.Migrator().CurrentDatabase()).Scan(&tables).Error
+    }
+    return tables, err
+}
+
+// dropAllTables 删除所有数据表，暂时关闭外键检查以免删除顺序出错
+func dropAllTables(db *gorm.DB) error {
+    tables, err := tableNames(db)
+    if err != nil {
+        return err
+    }
+
+    switch model.Connection() {
+    case "sqlite":
+        db.Exec("PRAGMA foreign_keys = OFF")
+        defer db.Exec("PRAGMA foreign_keys = ON")
+    default:
+        db.Exec("SET foreign_key_checks = 0")
+        defer db.Exec("SET foreign_key_checks = 1")
+    }
+
+    for _, table := range tables {
+        if err := db.Migrator().DropTable(table); err != nil {
+            return err
+        }
+    }
+    return nil
+}
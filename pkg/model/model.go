@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"goblog/pkg/config"
 	"goblog/pkg/logger"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
-	// GORM 的 MySQL 数据库驱动导入
+	// GORM 的 MySQL 和 SQLite 数据库驱动导入
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
 )
 
 var DB *gorm.DB
@@ -19,22 +24,6 @@ func ConnectDB() *gorm.DB {
 
     var err error
 
-    // 初始化 MySQL 连接信息
-    var (
-        host     = config.GetString("database.mysql.host")
-        port     = config.GetString("database.mysql.port")
-        database = config.GetString("database.mysql.database")
-        username = config.GetString("database.mysql.username")
-        password = config.GetString("database.mysql.password")
-        charset  = config.GetString("database.mysql.charset")
-    )
-    dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=%t&loc=%s",
-        username, password, host, port, database, charset, true, "Local")
-
-    gormConfig := mysql.New(mysql.Config{
-        DSN: dsn,
-    })
-
     var level gormlogger.LogLevel
     if config.GetBool("app.debug") {
         // 读取不到数据也会显示
@@ -45,11 +34,50 @@ func ConnectDB() *gorm.DB {
     }
 
     // 准备数据库连接池
-    DB, err = gorm.Open(gormConfig, &gorm.Config{
+    DB, err = gorm.Open(dialector(Connection()), &gorm.Config{
         Logger: gormlogger.Default.LogMode(level),
     })
 
-    logger.LogError(err)
+    if err != nil {
+        // 数据库不可用时无法提供服务，直接退出
+        logger.Fatal("数据库连接失败", zap.String("connection", Connection()), zap.Error(err))
+    }
 
     return DB
 }
+
+// Connection 当前使用的数据库连接名称，即 database.connection 配置
+func Connection() string {
+    return config.GetString("database.connection", "mysql")
+}
+
+// dialector 根据连接名称生成 GORM 的数据库驱动
+func dialector(connection string) gorm.Dialector {
+    switch connection {
+    case "sqlite":
+        // 初始化 SQLite 连接信息，数据库文件所在目录不存在时自动创建
+        database := config.GetString("database.sqlite.database")
+        if !strings.Contains(database, ":memory:") {
+            logger.LogError(os.MkdirAll(filepath.Dir(database), os.ModePerm))
+        }
+        return sqlite.Open(database)
+    case "mysql":
+        // 初始化 MySQL 连接信息
+        var (
+            host     = config.GetString("database.mysql.host")
+            port     = config.GetString("database.mysql.port")
+            database = config.GetString("database.mysql.database")
+            username = config.GetString("database.mysql.username")
+            password = config.GetString("database.mysql.password")
+            charset  = config.GetString("database.mysql.charset")
+        )
+        dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=%t&loc=%s",
+            username, password, host, port, database, charset, true, "Local")
+
+        return mysql.New(mysql.Config{
+            DSN: dsn,
+        })
+    }
+
+    panic(fmt.Sprintf("database: 不支持的数据库连接 %q，请检查 DB_CONNECTION 配置", connection))
+}
@@ -0,0 +1,205 @@
+// Package pagination 基于 GORM 查询的分页，页码从请求的 URL 参数中读取
+package pagination
+
+import (
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"goblog/pkg/route"
+	"goblog/pkg/types"
+	"math"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// onEachSide 页码链接中当前页两侧显示的页数，其余以省略号代替
+const onEachSide = 2
+
+// Page 分页链接中的一页，Ellipsis 为 true 时显示为省略号
+type Page struct {
+    Number   int    `json:"number"`
+    URL      string `json:"url"`
+    Active   bool   `json:"active"`
+    Ellipsis bool   `json:"-"`
+}
+
+// ViewData 模板和接口中使用的分页数据
+type ViewData struct {
+    // HasPages 是否需要显示分页
+    HasPages bool `json:"-"`
+
+    CurrentPage int   `json:"current_page"`
+    PerPage     int   `json:"per_page"`
+    TotalPage   int   `json:"total_page"`
+    TotalCount  int64 `json:"total_count"`
+
+    // 上一页和下一页的链接，不存在时为空字符串
+    PrevPageURL string `json:"prev_page_url"`
+    NextPageURL string `json:"next_page_url"`
+
+    Pages []Page `json:"-"`
+}
+
+// Pagination 分页对象
+type Pagination struct {
+    // BaseURL 分页链接的基础 URL，由路由名称生成
+    BaseURL string
+
+    PerPage int
+    Page    int
+    Count   int64
+
+    query *gorm.DB
+    req   *http.Request
+
+    // countErr 统计总条数时的错误，由 Results 返回
+    countErr error
+}
+
+// New 创建分页对象，perPage 小于等于零时使用 pagination.perpage 配置，
+// 分页链接通过 routeName 和 pars 使用 route.RouteName2URL 生成
+//
+// 用法：
+//
+//     query := model.DB.Model(Article{}).Order("created_at desc")
+//     _pager := pagination.New(r, query, 10, "home")
+//     viewData := _pager.Paging()
+//     _pager.Results(&articles)
+func New(r *http.Request, query *gorm.DB, perPage int, routeName string, pars ...string) *Pagination {
+    if perPage <= 0 {
+        perPage = config.GetInt("pagination.perpage")
+    }
+    if perPage <= 0 {
+        perPage = 10
+    }
+
+    p := &Pagination{
+        BaseURL: route.RouteName2URL(routeName, pars...),
+        PerPage: perPage,
+        Page:    1,
+        Count:   -1,
+        query:   query,
+        req:     r,
+    }
+    p.Page = p.currentPage()
+    return p
+}
+
+// WithTotal 设置已知的总条数，用于结果不来自 GORM 查询的场景（如搜索），之后不再执行 COUNT
+func (p *Pagination) WithTotal(total int64) *Pagination {
+    p.Count = total
+    return p
+}
+
+// Paging 返回渲染分页所需的数据
+func (p *Pagination) Paging() ViewData {
+    total := p.TotalCount()
+    totalPage := p.TotalPage()
+
+    data := ViewData{
+        HasPages:    totalPage > 1,
+        CurrentPage: p.Page,
+        PerPage:     p.PerPage,
+        TotalPage:   totalPage,
+        TotalCount:  total,
+    }
+    if p.Page > 1 && totalPage > 0 {
+        // 页码超出范围时，上一页指向最后一页
+        prev := p.Page - 1
+        if prev > totalPage {
+            prev = totalPage
+        }
+        data.PrevPageURL = p.pageURL(prev)
+    }
+    if p.Page < totalPage {
+        data.NextPageURL = p.pageURL(p.Page + 1)
+    }
+    data.Pages = p.pages(totalPage)
+    return data
+}
+
+// Results 读取当前页的数据，data 为模型切片的指针。
+// 之前统计总条数失败时直接返回该错误，避免数据库故障被显示为“没有数据”
+func (p *Pagination) Results(data interface{}) error {
+    if p.countErr != nil {
+        return p.countErr
+    }
+    return p.query.Session(&gorm.Session{}).
+        Limit(p.PerPage).
+        Offset(p.Offset()).
+        Find(data).Error
+}
+
+// Offset 当前页的数据偏移量
+func (p *Pagination) Offset() int {
+    return (p.Page - 1) * p.PerPage
+}
+
+// TotalCount 总条数，只查询一次。查询失败时记录日志并返回 0，错误由 Results 返回
+func (p *Pagination) TotalCount() int64 {
+    if p.Count == -1 {
+        var count int64
+        if err := p.query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+            logger.LogError(err)
+            p.countErr = err
+            return 0
+        }
+        p.Count = count
+    }
+    return p.Count
+}
+
+// TotalPage 总页数
+func (p *Pagination) TotalPage() int {
+    count := p.TotalCount()
+    if count == 0 {
+        return 0
+    }
+    return int(math.Ceil(float64(count) / float64(p.PerPage)))
+}
+
+// currentPage 从 URL 参数中读取页码，非法值视为第一页
+func (p *Pagination) currentPage() int {
+    page := p.req.URL.Query().Get(config.GetString("pagination.url_query"))
+    if page == "" {
+        return 1
+    }
+    i, err := strconv.Atoi(page)
+    if err != nil || i < 1 {
+        return 1
+    }
+    return i
+}
+
+// pageURL 生成指定页码的链接，保留请求中的其他 URL 参数，如搜索关键词
+func (p *Pagination) pageURL(page int) string {
+    values := p.req.URL.Query()
+    key := config.GetString("pagination.url_query")
+    if page <= 1 {
+        values.Del(key)
+    } else {
+        values.Set(key, types.Int64ToString(int64(page)))
+    }
+    if encoded := values.Encode(); encoded != "" {
+        return p.BaseURL + "?" + encoded
+    }
+    return p.BaseURL
+}
+
+// pages 页码链接：首页、末页，以及当前页两侧各 onEachSide 页，中间以省略号隔开
+func (p *Pagination) pages(totalPage int) []Page {
+    var pages []Page
+    last := 0
+    for i := 1; i <= totalPage; i++ {
+        if i != 1 && i != totalPage && (i < p.Page-onEachSide || i > p.Page+onEachSide) {
+            continue
+        }
+        if last > 0 && i-last > 1 {
+            pages = append(pages, Page{Ellipsis: true})
+        }
+        pages = append(pages, Page{Number: i, URL: p.pageURL(i), Active: i == p.Page})
+        last = i
+    }
+    return pages
+}
@@ -10,7 +10,10 @@ import (
 func Hash(password string) string {
     // GenerateFromPassword 的第二个参数是 cost 值。建议大于 12，数值越大耗费时间越长
     bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-    logger.LogError(err)
+    if err != nil {
+        logger.LogError(err)
+        return ""
+    }
 
     return string(bytes)
 }
@@ -18,7 +21,10 @@ func Hash(password string) string {
 // CheckHash 对比明文密码和数据库的哈希值
 func CheckHash(password, hash string) bool {
     err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-    logger.LogError(err)
+    // 密码不匹配是正常的业务情况，只记录其他错误（如哈希值格式错误）
+    if err != nil && err != bcrypt.ErrMismatchedHashAndPassword {
+        logger.LogError(err)
+    }
     return err == nil
 }
 
@@ -0,0 +1,62 @@
+// Package realip 获取请求的客户端 IP
+package realip
+
+import (
+	"goblog/pkg/config"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// FromRequest 获取客户端 IP。X-Forwarded-For 标头可被任意伪造，只有直接连接方
+// 在 app.trusted_proxies 中时才读取，并从右向左跳过其中的受信任代理
+func FromRequest(r *http.Request) string {
+    ip := hostOf(r.RemoteAddr)
+    if !isTrusted(ip) {
+        return ip
+    }
+
+    hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+    for i := len(hops) - 1; i >= 0; i-- {
+        hop := strings.TrimSpace(hops[i])
+        if len(hop) == 0 {
+            break
+        }
+        ip = hop
+        if !isTrusted(hop) {
+            break
+        }
+    }
+    return ip
+}
+
+// hostOf 去掉地址中的端口
+func hostOf(addr string) string {
+    host, _, err := net.SplitHostPort(addr)
+    if err != nil {
+        return addr
+    }
+    return host
+}
+
+// isTrusted ip 是否为受信任的代理，app.trusted_proxies 为逗号分隔的 IP 或 CIDR
+func isTrusted(ip string) bool {
+    parsed := net.ParseIP(ip)
+    if parsed == nil {
+        return false
+    }
+    for _, proxy := range strings.Split(config.GetString("app.trusted_proxies"), ",") {
+        proxy = strings.TrimSpace(proxy)
+        if len(proxy) == 0 {
+            continue
+        }
+        if _, network, err := net.ParseCIDR(proxy); err == nil {
+            if network.Contains(parsed) {
+                return true
+            }
+        } else if trusted := net.ParseIP(proxy); trusted != nil && trusted.Equal(parsed) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,42 @@
+// Package response 响应处理工具
+package response
+
+import (
+	"encoding/json"
+	"goblog/pkg/logger"
+	"net/http"
+	"strings"
+)
+
+// WantsJSON 客户端是否期望 JSON 响应，依据 Accept 标头判断，/api/ 下的请求始终为 JSON
+func WantsJSON(r *http.Request) bool {
+    return strings.HasPrefix(r.URL.Path, "/api/") ||
+        strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// JSON 以 JSON 格式响应数据
+func JSON(w http.ResponseWriter, status int, data interface{}) {
+    w.Header().Set("Content-Type", "application/json; charset=utf-8")
+    w.WriteHeader(status)
+    logger.LogError(json.NewEncoder(w).Encode(data))
+}
+
+// Error JSON 格式的错误响应，如 {"code":404,"message":"..."}
+func Error(w http.ResponseWriter, status int, message string) {
+    if len(message) == 0 {
+        message = http.StatusText(status)
+    }
+    JSON(w, status, map[string]interface{}{
+        "code":    status,
+        "message": message,
+    })
+}
+
+// ValidationError 表单验证失败，以 422 状态码返回各字段的错误信息
+func ValidationError(w http.ResponseWriter, errors map[string][]string) {
+    JSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+        "code":    http.StatusUnprocessableEntity,
+        "message": "请求参数验证失败",
+        "errors":  errors,
+    })
+}
@@ -1,10 +1,13 @@
 package route
 
 import (
+	"goblog/pkg/config"
 	"goblog/pkg/logger"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"go.uber.org/zap"
 )
 var route  *mux.Router
 
@@ -16,14 +19,25 @@ func SetRoute(r *mux.Router) {
 // RouteName2URL 通过路由名称来获取 URL
 func RouteName2URL(routerName string, pars ...string) string {
 
-    url, err := route.Get(routerName).URL(pars...)
+    namedRoute := route.Get(routerName)
+    if namedRoute == nil {
+        logger.Error("路由不存在", zap.String("name", routerName))
+        return ""
+    }
+
+    url, err := namedRoute.URL(pars...)
     if err != nil {
-        logger.LogError(err)
+        logger.LogError(err, zap.String("name", routerName))
         return ""
     }
     return url.String()
 }
 
+// URL 将站内路径转换为带域名的绝对链接，域名读取 app.url 配置
+func URL(path string) string {
+    return strings.TrimRight(config.GetString("app.url"), "/") + path
+}
+
 // 获取请求参数
 func GetRouterParam(parameterName string, r *http.Request) string {
     vars := mux.Vars(r)
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	v1 "goblog/app/http/controllers/api/v1"
+	middwares "goblog/app/http/middlewares"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAPIRoutes 注册 JSON 接口路由，按版本分组，如 /api/v1
+func RegisterAPIRoutes(r *mux.Router) {
+    api := r.PathPrefix("/api/v1").Subrouter()
+
+    // 支持以个人访问令牌认证
+    api.Use(middwares.AuthenticateToken)
+
+    // 文章
+    ac := new(v1.ArticlesController)
+    api.HandleFunc("/articles", ac.Index).Methods("GET").Name("api.v1.articles.index")
+    api.HandleFunc("/articles/{id:[0-9]+}", ac.Show).Methods("GET").Name("api.v1.articles.show")
+    api.HandleFunc("/articles", middwares.AuthAPI(middwares.Verified(canCreateArticles(ac.Store)))).Methods("POST").Name("api.v1.articles.store")
+    api.HandleFunc("/articles/{id:[0-9]+}", middwares.AuthAPI(ac.Update)).Methods("PUT", "PATCH").Name("api.v1.articles.update")
+    api.HandleFunc("/articles/{id:[0-9]+}", middwares.AuthAPI(ac.Delete)).Methods("DELETE").Name("api.v1.articles.delete")
+
+    // 用户
+    uc := new(v1.UsersController)
+    api.HandleFunc("/users/{id:[0-9]+}", uc.Show).Methods("GET").Name("api.v1.users.show")
+}
@@ -3,17 +3,22 @@ package routes
 import (
 	"goblog/app/http/controllers"
 	middwares "goblog/app/http/middlewares"
+	"goblog/app/models/role"
 	// middwares "goblog/app/http/middlewares"
 	"net/http"
 
 	"github.com/gorilla/mux"
 )
 
+// canCreateArticles 发布文章需要 articles.create 权限，读者角色没有
+var canCreateArticles = middwares.Authorize(role.PermissionCreateArticles)
+
 func RegisterWebRoutes(r *mux.Router) {
 	
 	//静态页面
 	pc := new(controllers.PackageController)
-	r.NotFoundHandler = http.HandlerFunc(pc.NotFound)
+	// 404 页面不经过 r.Use 注册的中间件，需单独开启会话以显示登录状态
+	r.NotFoundHandler = middwares.StartSession(http.HandlerFunc(pc.NotFound))
 	r.HandleFunc("/about", pc.About).Methods("GET").Name("about")
 	r.PathPrefix("/css/").Handler(http.FileServer(http.Dir("./public")))
 	r.PathPrefix("/js/").Handler(http.FileServer(http.Dir("./public")))
@@ -26,10 +31,17 @@ func RegisterWebRoutes(r *mux.Router) {
 	r.HandleFunc("/", ac.Index).Methods("GET").Name("home")
 	r.HandleFunc("/articles/{id:[0-9]+}/edit", middwares.Auth(ac.Edit)).Methods("GET").Name("articles.edit")
 	r.HandleFunc("/articles/{id:[0-9]+}", middwares.Auth(ac.Update)).Methods("POST").Name("articles.update")
-	r.HandleFunc("/articles/create", middwares.Auth(ac.Create)).Methods("GET").Name("articles.create")
-    r.HandleFunc("/articles", middwares.Auth(ac.Store)).Methods("POST").Name("articles.store")
+	r.HandleFunc("/articles/create", middwares.Auth(middwares.Verified(canCreateArticles(ac.Create)))).Methods("GET").Name("articles.create")
+    r.HandleFunc("/articles", middwares.Auth(middwares.Verified(canCreateArticles(ac.Store)))).Methods("POST").Name("articles.store")
 	r.HandleFunc("/articles/{id:[0-9]+}/delete", middwares.Auth(ac.Delete)).Methods("POST").Name("articles.delete")
 
+	// 文章评论
+	cmc := new(controllers.CommentsController)
+	r.HandleFunc("/articles/{id:[0-9]+}/comments", middwares.Auth(cmc.Store)).Methods("POST").Name("comments.store")
+	r.HandleFunc("/comments/{id:[0-9]+}/approve", middwares.Auth(cmc.Approve)).Methods("POST").Name("comments.approve")
+	r.HandleFunc("/comments/{id:[0-9]+}/spam", middwares.Auth(cmc.Spam)).Methods("POST").Name("comments.spam")
+	r.HandleFunc("/comments/{id:[0-9]+}/delete", middwares.Auth(cmc.Delete)).Methods("POST").Name("comments.delete")
+
 	// 用户相关
 	auc := new(controllers.AuthController)
 	r.HandleFunc("/auth/register", middwares.Guest(auc.Register)).Methods("GET").Name("auth.register")
@@ -38,11 +50,69 @@ func RegisterWebRoutes(r *mux.Router) {
     r.HandleFunc("/auth/dologin", middwares.Guest(auc.DoLogin)).Methods("POST").Name("auth.dologin")
     r.HandleFunc("/auth/logout", middwares.Auth(auc.Logout)).Methods("POST").Name("auth.logout")
 
+    // 邮箱验证
+    vc := new(controllers.VerificationController)
+    r.HandleFunc("/auth/verify-email", middwares.Auth(vc.Notice)).Methods("GET").Name("verification.notice")
+    r.HandleFunc("/auth/verify-email/{id:[0-9]+}/{hash}", vc.Verify).Methods("GET").Name("verification.verify")
+    r.HandleFunc("/auth/verify-email/resend", middwares.Auth(vc.Resend)).Methods("POST").Name("verification.resend")
+
+    // 找回密码
+    pwc := new(controllers.PasswordController)
+    r.HandleFunc("/auth/password/forgot", middwares.Guest(pwc.ShowForgotForm)).Methods("GET").Name("auth.password.request")
+    r.HandleFunc("/auth/password/email", middwares.Guest(pwc.SendResetLink)).Methods("POST").Name("auth.password.email")
+    r.HandleFunc("/auth/password/reset/{token}", middwares.Guest(pwc.ShowResetForm)).Methods("GET").Name("auth.password.reset")
+    r.HandleFunc("/auth/password/reset", middwares.Guest(pwc.Reset)).Methods("POST").Name("auth.password.update")
+
 	// 用户认证
     uc := new(controllers.UserController)
     r.HandleFunc("/users/{id:[0-9]+}", uc.Show).Methods("GET").Name("users.show")
+
+    // 个人访问令牌
+    tkc := new(controllers.TokensController)
+    r.HandleFunc("/settings/tokens", middwares.Auth(tkc.Index)).Methods("GET").Name("settings.tokens")
+    r.HandleFunc("/settings/tokens", middwares.Auth(tkc.Store)).Methods("POST").Name("settings.tokens.store")
+    r.HandleFunc("/settings/tokens/{id:[0-9]+}/delete", middwares.Auth(tkc.Delete)).Methods("POST").Name("settings.tokens.delete")
+
+    // 用户管理
+    amc := new(controllers.AdminUsersController)
+    canManageUsers := middwares.Authorize(role.PermissionManageUsers)
+    r.HandleFunc("/admin/users", middwares.Auth(canManageUsers(amc.Index))).Methods("GET").Name("admin.users")
+    r.HandleFunc("/admin/users/{id:[0-9]+}/roles", middwares.Auth(canManageUsers(amc.UpdateRoles))).Methods("POST").Name("admin.users.roles")
+
+    // 文章分类
+    cc := new(controllers.CategoriesController)
+    r.HandleFunc("/categories/{id:[0-9]+}", cc.Show).Methods("GET").Name("categories.show")
+
+    // 订阅源
+    fc := new(controllers.FeedsController)
+    r.HandleFunc("/feed.xml", fc.RSS).Methods("GET").Name("feeds.rss")
+    r.HandleFunc("/atom.xml", fc.Atom).Methods("GET").Name("feeds.atom")
+    r.HandleFunc("/feed.json", fc.JSON).Methods("GET").Name("feeds.json")
+    r.HandleFunc("/users/{id:[0-9]+}/feed.xml", fc.User).Methods("GET").Name("users.feed")
+
+    // 站点地图
+    smc := new(controllers.SitemapController)
+    r.HandleFunc("/sitemap.xml", smc.Index).Methods("GET").Name("sitemap.index")
+    r.HandleFunc("/sitemap-{page:[0-9]+}.xml", smc.Page).Methods("GET").Name("sitemap.page")
+    r.HandleFunc("/robots.txt", smc.Robots).Methods("GET").Name("robots")
+
+    // 搜索
+    sc := new(controllers.SearchController)
+    r.HandleFunc("/search", sc.Index).Methods("GET").Name("search")
+
+    // 文章标签
+    tc := new(controllers.TagsController)
+    r.HandleFunc("/tags/{slug}", tc.Show).Methods("GET").Name("tags.show")
 	
 	// --- 全局中间件 ---
     // 开始会话
     r.Use(middwares.StartSession)
+    // 请求内缓存角色和权限
+    r.Use(middwares.CacheGrants)
+    // 请求日志，需在会话之后
+    r.Use(middwares.RequestLogger)
+    // 捕获 panic 并显示 500 页面
+    r.Use(middwares.Recover)
+    // 校验表单的 CSRF 令牌，419 页面依赖会话，需在会话之后
+    r.Use(middwares.VerifyCSRFToken)
 }
\ No newline at end of file
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Highlight 截取 text 中第一个关键词附近的 length 个字，并以 <mark> 标记所有关键词，
+// 其余内容经过转义。length 小于等于零时不截取，用于标题
+func Highlight(text string, keywords string, length int) template.HTML {
+    runes := []rune(strings.Join(strings.Fields(text), " "))
+    lower := make([]rune, len(runes))
+    for i, r := range runes {
+        lower[i] = unicode.ToLower(r)
+    }
+
+    // 优先匹配较长的关键词
+    var terms [][]rune
+    for _, word := range strings.Fields(strings.ToLower(keywords)) {
+        terms = append(terms, []rune(word))
+    }
+    sort.Slice(terms, func(i, j int) bool {
+        return len(terms[i]) > len(terms[j])
+    })
+
+    matchAt := func(i int) int {
+        for _, term := range terms {
+            if i+len(term) <= len(lower) && string(lower[i:i+len(term)]) == string(term) {
+                return len(term)
+            }
+        }
+        return 0
+    }
+
+    // 摘要从第一个关键词之前的一小段开始
+    start, end := 0, len(runes)
+    if length > 0 && len(runes) > length {
+        first := 0
+        for i := range lower {
+            if matchAt(i) > 0 {
+                first = i
+                break
+            }
+        }
+        start = first - length/4
+        if start < 0 {
+            start = 0
+        }
+        // 英文尽量从单词开头截取
+        for i := start; start > 0 && i < first; i++ {
+            if runes[i] == ' ' {
+                start = i + 1
+                break
+            }
+        }
+        if start+length > len(runes) {
+            start = len(runes) - length
+        }
+        end = start + length
+    }
+
+    var b strings.Builder
+    if start > 0 {
+        b.WriteString("…")
+    }
+    plain := start
+    for i := start; i < end; {
+        n := matchAt(i)
+        if n == 0 {
+            i++
+            continue
+        }
+        if i+n > end {
+            n = end - i
+        }
+        b.WriteString(template.HTMLEscapeString(string(runes[plain:i])))
+        b.WriteString("<mark>")
+        b.WriteString(template.HTMLEscapeString(string(runes[i : i+n])))
+        b.WriteString("</mark>")
+        i += n
+        plain = i
+    }
+    b.WriteString(template.HTMLEscapeString(string(runes[plain:end])))
+    if end < len(runes) {
+        b.WriteString("…")
+    }
+    return template.HTML(b.String())
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"sort"
+	"sync"
+)
+
+// titleWeight 标题中的词在相关度中的权重
+const titleWeight = 3
+
+// MemoryEngine 进程内的倒排索引，重启后需通过 Rebuild 重建
+type MemoryEngine struct {
+    mu sync.RWMutex
+
+    // postings 词 => 文章 ID => 相关度
+    postings map[string]map[uint64]float64
+    docs     map[uint64]indexedDoc
+}
+
+// indexedDoc 用于过滤结果和删除索引的文章信息
+type indexedDoc struct {
+    Document
+    tokens []string
+}
+
+// NewMemoryEngine 创建空的内存索引
+func NewMemoryEngine() *MemoryEngine {
+    return &MemoryEngine{
+        postings: make(map[string]map[uint64]float64),
+        docs:     make(map[uint64]indexedDoc),
+    }
+}
+
+// Rebuild 清空并重建索引
+func (e *MemoryEngine) Rebuild(docs []Document) error {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    e.postings = make(map[string]map[uint64]float64)
+    e.docs = make(map[uint64]indexedDoc, len(docs))
+    for _, doc := range docs {
+        e.add(doc)
+    }
+    return nil
+}
+
+// Index 将文章加入索引，已存在时更新
+func (e *MemoryEngine) Index(doc Document) error {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    e.remove(doc.ID)
+    e.add(doc)
+    return nil
+}
+
+// Remove 从索引中删除文章
+func (e *MemoryEngine) Remove(id uint64) error {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    e.remove(id)
+    return nil
+}
+
+// Search 搜索包含全部关键词的文章，按相关度和发布时间排序
+func (e *MemoryEngine) Search(q Query) (Result, error) {
+    var result Result
+    tokens := unique(Tokenize(q.Keywords))
+    if len(tokens) == 0 {
+        return result, nil
+    }
+
+    e.mu.RLock()
+    defer e.mu.RUnlock()
+
+    // 从文章最少的词开始求交集
+    sort.Slice(tokens, func(i, j int) bool {
+        return len(e.postings[tokens[i]]) < len(e.postings[tokens[j]])
+    })
+
+    type hit struct {
+        doc   indexedDoc
+        score float64
+    }
+    var hits []hit
+    for id, score := range e.postings[tokens[0]] {
+        doc := e.docs[id]
+        if !matchFilters(doc.Document, q) {
+            continue
+        }
+        matched := true
+        for _, token := range tokens[1:] {
+            s, ok := e.postings[token][id]
+            if !ok {
+                matched = false
+                break
+            }
+            score += s
+        }
+        if matched {
+            hits = append(hits, hit{doc: doc, score: score})
+        }
+    }
+
+    sort.Slice(hits, func(i, j int) bool {
+        if hits[i].score != hits[j].score {
+            return hits[i].score > hits[j].score
+        }
+        if !hits[i].doc.CreatedAt.Equal(hits[j].doc.CreatedAt) {
+            return hits[i].doc.CreatedAt.After(hits[j].doc.CreatedAt)
+        }
+        return hits[i].doc.ID > hits[j].doc.ID
+    })
+
+    result.Total = int64(len(hits))
+    for i := q.Offset; i < len(hits) && (q.Limit <= 0 || i < q.Offset+q.Limit); i++ {
+        result.IDs = append(result.IDs, hits[i].doc.ID)
+    }
+    return result, nil
+}
+
+func (e *MemoryEngine) add(doc Document) {
+    scores := make(map[string]float64)
+    for _, token := range IndexTokens(doc.Title) {
+        scores[token] += titleWeight
+    }
+    for _, token := range IndexTokens(doc.Body) {
+        scores[token]++
+    }
+
+    tokens := make([]string, 0, len(scores))
+    for token, score := range scores {
+        if e.postings[token] == nil {
+            e.postings[token] = make(map[uint64]float64)
+        }
+        e.postings[token][doc.ID] = score
+        tokens = append(tokens, token)
+    }
+    e.docs[doc.ID] = indexedDoc{Document: doc, tokens: tokens}
+}
+
+func (e *MemoryEngine) remove(id uint64) {
+    doc, ok := e.docs[id]
+    if !ok {
+        return
+    }
+    for _, token := range doc.tokens {
+        delete(e.postings[token], id)
+        if len(e.postings[token]) == 0 {
+            delete(e.postings, token)
+        }
+    }
+    delete(e.docs, id)
+}
+
+// matchFilters 是否满足作者和发布时间的过滤条件
+func matchFilters(doc Document, q Query) bool {
+    if q.UserID > 0 && doc.UserID != q.UserID {
+        return false
+    }
+    if !q.From.IsZero() && doc.CreatedAt.Before(q.From) {
+        return false
+    }
+    if !q.To.IsZero() && doc.CreatedAt.After(q.To) {
+        return false
+    }
+    return true
+}
+
+func unique(tokens []string) []string {
+    seen := make(map[string]bool, len(tokens))
+    var result []string
+    for _, token := range tokens {
+        if !seen[token] {
+            seen[token] = true
+            result = append(result, token)
+        }
+    }
+    return result
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"goblog/pkg/model"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MySQLEngine 使用 articles 表上 title、body 两列的 FULLTEXT 索引搜索，
+// 索引由 MySQL 维护，Index 和 Remove 无需任何操作。中文依赖索引的 ngram 分词器
+type MySQLEngine struct{}
+
+// NewMySQLEngine 创建 MySQL 全文搜索引擎
+func NewMySQLEngine() *MySQLEngine {
+    return &MySQLEngine{}
+}
+
+// matchSQL 全文匹配条件，使用布尔模式要求包含全部关键词
+const matchSQL = "MATCH(title, body) AGAINST(? IN BOOLEAN MODE)"
+
+// Search 搜索文章，按相关度排序
+func (e *MySQLEngine) Search(q Query) (Result, error) {
+    var result Result
+    against := booleanQuery(q.Keywords)
+    if against == "" {
+        return result, nil
+    }
+
+    query := e.filter(model.DB.Table("articles").Where(matchSQL, against), q)
+    if err := query.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+        return result, err
+    }
+
+    // 按相关度排序，相关度需要绑定查询参数，无法使用 Order 方法
+    query = query.Select("id").
+        Clauses(clause.OrderBy{Expression: clause.Expr{
+            SQL:                matchSQL + " DESC, created_at DESC",
+            Vars:               []interface{}{against},
+            WithoutParentheses: true,
+        }}).
+        Offset(q.Offset)
+    if q.Limit > 0 {
+        query = query.Limit(q.Limit)
+    }
+    err := query.Pluck("id", &result.IDs).Error
+    return result, err
+}
+
+// Index 索引由 MySQL 维护
+func (e *MySQLEngine) Index(doc Document) error {
+    return nil
+}
+
+// Remove 索引由 MySQL 维护
+func (e *MySQLEngine) Remove(id uint64) error {
+    return nil
+}
+
+func (e *MySQLEngine) filter(query *gorm.DB, q Query) *gorm.DB {
+    if q.UserID > 0 {
+        query = query.Where("user_id = ?", q.UserID)
+    }
+    if !q.From.IsZero() {
+        query = query.Where("created_at >= ?", q.From)
+    }
+    if !q.To.IsZero() {
+        query = query.Where("created_at <= ?", q.To)
+    }
+    return query
+}
+
+// booleanQuery 将关键词转换为布尔模式的查询，每个词作为必须包含的短语，
+// 去掉布尔模式的操作符，避免用户输入被解析为查询语法
+func booleanQuery(keywords string) string {
+    var terms []string
+    for _, word := range strings.Fields(keywords) {
+        word = strings.Map(func(r rune) rune {
+            if unicode.IsLetter(r) || unicode.IsDigit(r) {
+                return r
+            }
+            return ' '
+        }, word)
+        if word = strings.Join(strings.Fields(word), " "); word != "" {
+            terms = append(terms, `+"`+word+`"`)
+        }
+    }
+    return strings.Join(terms, " ")
+}
@@ -0,0 +1,95 @@
+// Package search 文章全文搜索，MySQL 下使用 FULLTEXT 索引，其他数据库使用进程内的倒排索引
+package search
+
+import (
+	"goblog/pkg/config"
+	"goblog/pkg/model"
+	"sync"
+	"time"
+)
+
+// Document 被索引的文章
+type Document struct {
+    ID        uint64
+    UserID    uint64
+    Title     string
+    Body      string
+    CreatedAt time.Time
+}
+
+// Query 搜索条件，UserID 为 0 及时间为零值时不做对应的过滤
+type Query struct {
+    Keywords string
+    UserID   uint64
+
+    // From 和 To 为发布时间的范围，包含两端
+    From time.Time
+    To   time.Time
+
+    Offset int
+    Limit  int
+}
+
+// Result 搜索结果，IDs 为当前页的文章 ID，按相关度排序
+type Result struct {
+    IDs   []uint64
+    Total int64
+}
+
+// Engine 搜索引擎
+type Engine interface {
+    Search(q Query) (Result, error)
+    Index(doc Document) error
+    Remove(id uint64) error
+}
+
+// Rebuilder 索引保存在进程内的引擎需要在启动时重建索引
+type Rebuilder interface {
+    Rebuild(docs []Document) error
+}
+
+var (
+    engine     Engine
+    engineOnce sync.Once
+)
+
+// Default 获取搜索引擎，首次使用时根据 search.driver 配置创建，未配置时按数据库连接选择
+func Default() Engine {
+    engineOnce.Do(func() {
+        if engine != nil {
+            return
+        }
+        driver := config.GetString("search.driver")
+        if driver == "" {
+            driver = model.Connection()
+        }
+        switch driver {
+        case "mysql":
+            engine = NewMySQLEngine()
+        default:
+            engine = NewMemoryEngine()
+        }
+    })
+    return engine
+}
+
+// SetEngine 指定搜索引擎，用于测试
+func SetEngine(e Engine) {
+    engineOnce.Do(func() {})
+    engine = e
+}
+
+// Search 使用默认引擎搜索
+func Search(q Query) (Result, error) {
+    return Default().Search(q)
+}
+
+// Index 将文章加入索引，已存在时更新
+func Index(doc Document) error {
+    return Default().Index(doc)
+}
+
+// Remove 从索引中删除文章
+func Remove(id uint64) error {
+    return Default().Remove(id)
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 分词：英文和数字按单词切分并转为小写，中日韩文字没有空格分隔，按相邻两字切分（bigram），
+// 单独的一个字保留为一个词。查询时使用此分词，要求包含全部词
+func Tokenize(text string) []string {
+    return tokenize(text, false)
+}
+
+// IndexTokens 建立索引时的分词，在 Tokenize 的基础上加入连续中日韩文字中的每个字，
+// 使单个字的查询（如“博”）也能匹配出现在词语中的该字
+func IndexTokens(text string) []string {
+    return tokenize(text, true)
+}
+
+func tokenize(text string, unigrams bool) []string {
+    var tokens []string
+    var word []rune
+    var cjk []rune
+
+    flushWord := func() {
+        if len(word) > 0 {
+            tokens = append(tokens, string(word))
+            word = word[:0]
+        }
+    }
+    flushCJK := func() {
+        switch len(cjk) {
+        case 0:
+        case 1:
+            tokens = append(tokens, string(cjk))
+        default:
+            for i := 0; i < len(cjk)-1; i++ {
+                tokens = append(tokens, string(cjk[i:i+2]))
+            }
+            if unigrams {
+                for _, r := range cjk {
+                    tokens = append(tokens, string(r))
+                }
+            }
+        }
+        cjk = cjk[:0]
+    }
+
+    for _, r := range strings.ToLower(text) {
+        switch {
+        case isCJK(r):
+            flushWord()
+            cjk = append(cjk, r)
+        case unicode.IsLetter(r) || unicode.IsDigit(r):
+            flushCJK()
+            word = append(word, r)
+        default:
+            flushWord()
+            flushCJK()
+        }
+    }
+    flushWord()
+    flushCJK()
+
+    return tokens
+}
+
+func isCJK(r rune) bool {
+    return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
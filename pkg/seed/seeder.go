@@ -0,0 +1,97 @@
+// Package seed 数据填充，由 database/seeders 下的文件注册填充方法
+package seed
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SeederFunc 填充方法
+type SeederFunc func(db *gorm.DB) error
+
+// Seeder 对应每一个 database/seeders 目录下的 Seeder 文件
+type Seeder struct {
+    Func SeederFunc
+    Name string
+}
+
+// seeders 存放所有 Seeder
+var seeders []Seeder
+
+// orderedSeederNames 按顺序执行的 Seeder 数组，支持一些必须按顺序执行的 seeder，
+// 例如 articles 依赖于 users 的数据，就必须先执行 users 的填充
+var orderedSeederNames []string
+
+// Add 注册 Seeder 到 seeders 数组中
+func Add(name string, fn SeederFunc) {
+    seeders = append(seeders, Seeder{
+        Name: name,
+        Func: fn,
+    })
+}
+
+// SetRunOrder 设置『按顺序执行的 Seeder 数组』
+func SetRunOrder(names []string) {
+    orderedSeederNames = names
+}
+
+// GetSeeder 通过名称来获取 Seeder 对象
+func GetSeeder(name string) (Seeder, bool) {
+    for _, sdr := range seeders {
+        if name == sdr.Name {
+            return sdr, true
+        }
+    }
+    return Seeder{}, false
+}
+
+// Names 所有 Seeder 的名称，按执行顺序排列
+func Names() []string {
+    var names []string
+    for _, sdr := range ordered() {
+        names = append(names, sdr.Name)
+    }
+    return names
+}
+
+// RunAll 运行所有 Seeder，先执行设置了顺序的，再执行其余的
+func RunAll(db *gorm.DB) error {
+    for _, sdr := range ordered() {
+        if err := sdr.Func(db); err != nil {
+            return fmt.Errorf("seed: 运行 %s 失败：%w", sdr.Name, err)
+        }
+    }
+    return nil
+}
+
+// RunSeeder 运行单个 Seeder
+func RunSeeder(db *gorm.DB, name string) error {
+    sdr, ok := GetSeeder(name)
+    if !ok {
+        return fmt.Errorf("seed: 找不到名为 %s 的 Seeder", name)
+    }
+    if err := sdr.Func(db); err != nil {
+        return fmt.Errorf("seed: 运行 %s 失败：%w", name, err)
+    }
+    return nil
+}
+
+// ordered 按执行顺序排列的 Seeder
+func ordered() []Seeder {
+    var result []Seeder
+    executed := make(map[string]bool)
+
+    for _, name := range orderedSeederNames {
+        if sdr, ok := GetSeeder(name); ok {
+            result = append(result, sdr)
+            executed[name] = true
+        }
+    }
+    for _, sdr := range seeders {
+        if !executed[sdr.Name] {
+            result = append(result, sdr)
+        }
+    }
+    return result
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"goblog/pkg/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseHandler 将会话保存在数据库的 sessions 表中
+type DatabaseHandler struct{}
+
+// NewDatabaseHandler 创建数据库会话处理器，使用 model.DB 连接
+func NewDatabaseHandler() *DatabaseHandler {
+    return &DatabaseHandler{}
+}
+
+// Read 读取会话记录
+func (*DatabaseHandler) Read(id string) (Record, error) {
+    var rec Record
+    err := model.DB.Where("id = ?", id).First(&rec).Error
+    if err == gorm.ErrRecordNotFound {
+        return rec, ErrSessionNotFound
+    }
+    return rec, err
+}
+
+// Write 写入会话记录
+func (*DatabaseHandler) Write(rec Record) error {
+    return model.DB.Save(&rec).Error
+}
+
+// Destroy 删除指定会话
+func (*DatabaseHandler) Destroy(id string) error {
+    return model.DB.Where("id = ?", id).Delete(&Record{}).Error
+}
+
+// UserRecords 获取用户的全部会话，最近活跃的排在前面
+func (*DatabaseHandler) UserRecords(uid string) ([]Record, error) {
+    var records []Record
+    err := model.DB.Where("user_id = ?", uid).Order("last_activity desc").Find(&records).Error
+    return records, err
+}
+
+// DestroyUser 删除用户的全部会话
+func (*DatabaseHandler) DestroyUser(uid string) error {
+    return model.DB.Where("user_id = ?", uid).Delete(&Record{}).Error
+}
+
+// GC 清理过期会话
+func (*DatabaseHandler) GC(now time.Time) error {
+    return model.DB.Where("expires_at <= ?", now).Delete(&Record{}).Error
+}
@@ -0,0 +1,157 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"goblog/pkg/config"
+	"time"
+
+	"github.com/gorilla/sessions"
+)
+
+// Driver 会话驱动，所有驱动都需实现 gorilla 的 sessions.Store 接口
+type Driver interface {
+    sessions.Store
+}
+
+// Handler 服务端会话的存储处理器，database 和 file 驱动各自实现
+type Handler interface {
+    // Read 读取会话记录，不存在时返回 ErrSessionNotFound
+    Read(id string) (Record, error)
+    // Write 写入（新增或覆盖）会话记录
+    Write(record Record) error
+    // Destroy 删除指定会话
+    Destroy(id string) error
+    // UserRecords 获取用户的全部会话记录
+    UserRecords(uid string) ([]Record, error)
+    // DestroyUser 删除用户的全部会话
+    DestroyUser(uid string) error
+    // GC 清理在 now 之前过期的会话
+    GC(now time.Time) error
+}
+
+// Record 服务端保存的会话记录
+type Record struct {
+    ID           string    `gorm:"column:id;type:varchar(64);primaryKey"`
+    UserID       string    `gorm:"column:user_id;type:varchar(32);index"`
+    Payload      []byte    `gorm:"column:payload"`
+    IPAddress    string    `gorm:"column:ip_address;type:varchar(45)"`
+    UserAgent    string    `gorm:"column:user_agent;type:varchar(255)"`
+    LastActivity time.Time `gorm:"column:last_activity"`
+    ExpiresAt    time.Time `gorm:"column:expires_at;index"`
+}
+
+// TableName 会话数据表名称，可通过 session.table 配置
+func (Record) TableName() string {
+    return config.GetString("session.table", "sessions")
+}
+
+// Expired 会话是否已过期
+func (rec Record) Expired() bool {
+    return !rec.ExpiresAt.After(time.Now())
+}
+
+var (
+    // ErrSessionNotFound 会话记录不存在
+    ErrSessionNotFound = errors.New("session: 会话不存在")
+
+    // ErrNotSupported 当前驱动不支持服务端管理会话（如 cookie 驱动）
+    ErrNotSupported = errors.New("session: 当前会话驱动不支持此操作")
+)
+
+// NewDriver 根据驱动名称创建会话驱动
+func NewDriver(name string) (Driver, error) {
+    keyPairs := []byte(config.GetString("app.key"))
+    options := defaultOptions()
+
+    switch name {
+    case "cookie":
+        store := sessions.NewCookieStore(keyPairs)
+        store.Options = options
+        // 同时限制 securecookie 中签名的有效期，过期的 Cookie 会被服务端拒绝
+        store.MaxAge(options.MaxAge)
+        return store, nil
+    case "database":
+        return NewServerStore(NewDatabaseHandler(), options, keyPairs), nil
+    case "file":
+        handler, err := NewFileHandler(config.GetString("session.files"))
+        if err != nil {
+            return nil, err
+        }
+        return NewServerStore(handler, options, keyPairs), nil
+    }
+
+    return nil, fmt.Errorf("session: 不支持的会话驱动 %q", name)
+}
+
+// defaultOptions 会话 Cookie 的默认选项
+func defaultOptions() *sessions.Options {
+    return &sessions.Options{
+        Path:     "/",
+        MaxAge:   config.GetInt("session.lifetime", 120) * 60,
+        HttpOnly: true,
+    }
+}
+
+// handler 获取服务端会话处理器，cookie 驱动返回 ErrNotSupported
+func handler() (Handler, error) {
+    store, ok := getStore().(*ServerStore)
+    if !ok {
+        return nil, ErrNotSupported
+    }
+    return store.Handler, nil
+}
+
+// UserSessions 列出用户当前所有未过期的会话（登录设备）
+func UserSessions(uid string) ([]Record, error) {
+    h, err := handler()
+    if err != nil {
+        return nil, err
+    }
+    records, err := h.UserRecords(uid)
+    if err != nil {
+        return nil, err
+    }
+
+    active := records[:0]
+    for _, rec := range records {
+        if !rec.Expired() {
+            active = append(active, rec)
+        }
+    }
+    return active, nil
+}
+
+// Revoke 注销用户的某一个会话，只允许注销属于该用户的会话
+func Revoke(uid string, id string) error {
+    h, err := handler()
+    if err != nil {
+        return err
+    }
+    rec, err := h.Read(id)
+    if err != nil {
+        return err
+    }
+    if rec.UserID != uid {
+        return ErrSessionNotFound
+    }
+    return h.Destroy(id)
+}
+
+// RevokeUser 强制注销用户的所有会话，用户在所有设备上都会退出登录
+func RevokeUser(uid string) error {
+    h, err := handler()
+    if err != nil {
+        return err
+    }
+    return h.DestroyUser(uid)
+}
+
+// GC 清理所有已过期的会话
+func GC() error {
+    h, err := handler()
+    if err != nil {
+        return err
+    }
+    return h.GC(time.Now())
+}
@@ -0,0 +1,156 @@
+package session
+
+import (
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileHandler 将会话保存在文件系统中，每个会话一个文件
+type FileHandler struct {
+    Path string
+    mu   sync.RWMutex
+}
+
+// NewFileHandler 创建文件会话处理器，目录不存在时自动创建
+func NewFileHandler(path string) (*FileHandler, error) {
+    if err := os.MkdirAll(path, 0700); err != nil {
+        return nil, err
+    }
+    return &FileHandler{Path: path}, nil
+}
+
+// Read 读取会话记录
+func (h *FileHandler) Read(id string) (Record, error) {
+    h.mu.RLock()
+    defer h.mu.RUnlock()
+    return h.read(h.filename(id))
+}
+
+// Write 写入会话记录，先写临时文件再重命名，避免读到写了一半的文件
+func (h *FileHandler) Write(rec Record) error {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    tmp, err := ioutil.TempFile(h.Path, "tmp_")
+    if err != nil {
+        return err
+    }
+    if err = gob.NewEncoder(tmp).Encode(rec); err != nil {
+        tmp.Close()
+        os.Remove(tmp.Name())
+        return err
+    }
+    if err = tmp.Close(); err != nil {
+        os.Remove(tmp.Name())
+        return err
+    }
+    return os.Rename(tmp.Name(), h.filename(rec.ID))
+}
+
+// Destroy 删除指定会话
+func (h *FileHandler) Destroy(id string) error {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    return removeFile(h.filename(id))
+}
+
+// UserRecords 获取用户的全部会话，最近活跃的排在前面
+func (h *FileHandler) UserRecords(uid string) ([]Record, error) {
+    h.mu.RLock()
+    defer h.mu.RUnlock()
+
+    var records []Record
+    err := h.each(func(file string, rec Record) error {
+        if rec.UserID == uid {
+            records = append(records, rec)
+        }
+        return nil
+    })
+    sort.Slice(records, func(i, j int) bool {
+        return records[i].LastActivity.After(records[j].LastActivity)
+    })
+    return records, err
+}
+
+// DestroyUser 删除用户的全部会话
+func (h *FileHandler) DestroyUser(uid string) error {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    return h.each(func(file string, rec Record) error {
+        if rec.UserID == uid {
+            return removeFile(file)
+        }
+        return nil
+    })
+}
+
+// GC 清理过期会话
+func (h *FileHandler) GC(now time.Time) error {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    return h.each(func(file string, rec Record) error {
+        if !rec.ExpiresAt.After(now) {
+            return removeFile(file)
+        }
+        return nil
+    })
+}
+
+// each 遍历所有会话文件，无法解析的文件会被跳过
+func (h *FileHandler) each(fn func(file string, rec Record) error) error {
+    files, err := filepath.Glob(filepath.Join(h.Path, "session_*"))
+    if err != nil {
+        return err
+    }
+    for _, file := range files {
+        rec, err := h.read(file)
+        if err != nil {
+            continue
+        }
+        if err = fn(file, rec); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func (h *FileHandler) read(file string) (Record, error) {
+    var rec Record
+    f, err := os.Open(file)
+    if os.IsNotExist(err) {
+        return rec, ErrSessionNotFound
+    }
+    if err != nil {
+        return rec, err
+    }
+    defer f.Close()
+
+    err = gob.NewDecoder(f).Decode(&rec)
+    return rec, err
+}
+
+// filename 会话文件路径，会话 ID 只包含 base32 字符，过滤分隔符以防路径穿越
+func (h *FileHandler) filename(id string) string {
+    id = strings.Map(func(r rune) rune {
+        if r == '/' || r == '\\' || r == '.' {
+            return -1
+        }
+        return r
+    }, id)
+    return filepath.Join(h.Path, "session_"+id)
+}
+
+func removeFile(file string) error {
+    if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+        return err
+    }
+    return nil
+}
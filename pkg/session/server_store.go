@@ -0,0 +1,189 @@
+package session
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/gob"
+	"goblog/pkg/logger"
+	"goblog/pkg/realip"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// ServerStore 服务端会话存储，Cookie 中只保存签名后的会话 ID，数据由 Handler 保存
+type ServerStore struct {
+    Handler Handler
+    Codecs  []securecookie.Codec
+    Options *sessions.Options
+
+    // 每次保存会话时，有 1/GCLottery 的概率清理过期会话
+    GCLottery int64
+}
+
+// NewServerStore 创建服务端会话存储
+func NewServerStore(handler Handler, options *sessions.Options, keyPairs ...[]byte) *ServerStore {
+    store := &ServerStore{
+        Handler:   handler,
+        Codecs:    securecookie.CodecsFromPairs(keyPairs...),
+        Options:   options,
+        GCLottery: 100,
+    }
+    for _, codec := range store.Codecs {
+        if sc, ok := codec.(*securecookie.SecureCookie); ok {
+            sc.MaxAge(options.MaxAge)
+        }
+    }
+    return store
+}
+
+// Get 获取会话，同一请求内多次获取返回同一个会话
+func (s *ServerStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+    return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New 创建会话，如 Cookie 中携带有效的会话 ID 则从服务端加载数据
+func (s *ServerStore) New(r *http.Request, name string) (*sessions.Session, error) {
+    _session := sessions.NewSession(s, name)
+    opts := *s.Options
+    _session.Options = &opts
+    _session.IsNew = true
+
+    cookie, err := r.Cookie(name)
+    if err != nil {
+        return _session, nil
+    }
+
+    var id string
+    if err = securecookie.DecodeMulti(name, cookie.Value, &id, s.Codecs...); err != nil {
+        // Cookie 已过期或被篡改，当作新会话处理
+        return _session, nil
+    }
+
+    rec, err := s.Handler.Read(id)
+    if err != nil {
+        if err == ErrSessionNotFound {
+            return _session, nil
+        }
+        return _session, err
+    }
+    if rec.Expired() {
+        return _session, s.Handler.Destroy(id)
+    }
+
+    if err = decodeValues(rec.Payload, &_session.Values); err != nil {
+        return _session, err
+    }
+    _session.ID = id
+    _session.IsNew = false
+
+    return _session, nil
+}
+
+// Save 保存会话，MaxAge 小于 0 时删除会话
+func (s *ServerStore) Save(r *http.Request, w http.ResponseWriter, _session *sessions.Session) error {
+    if _session.Options.MaxAge < 0 {
+        if len(_session.ID) > 0 {
+            if err := s.Handler.Destroy(_session.ID); err != nil {
+                return err
+            }
+        }
+        http.SetCookie(w, sessions.NewCookie(_session.Name(), "", _session.Options))
+        return nil
+    }
+
+    if len(_session.ID) == 0 {
+        _session.ID = newSessionID()
+    }
+
+    payload, err := encodeValues(_session.Values)
+    if err != nil {
+        return err
+    }
+
+    now := time.Now()
+    rec := Record{
+        ID:           _session.ID,
+        UserID:       userID(_session),
+        Payload:      payload,
+        IPAddress:    realip.FromRequest(r),
+        UserAgent:    truncate(r.UserAgent(), 255),
+        LastActivity: now,
+        ExpiresAt:    now.Add(time.Duration(_session.Options.MaxAge) * time.Second),
+    }
+    if err = s.Handler.Write(rec); err != nil {
+        return err
+    }
+
+    encoded, err := securecookie.EncodeMulti(_session.Name(), _session.ID, s.Codecs...)
+    if err != nil {
+        return err
+    }
+    http.SetCookie(w, sessions.NewCookie(_session.Name(), encoded, _session.Options))
+
+    s.collectGarbage(now)
+
+    return nil
+}
+
+// Regenerate 删除服务端的旧会话并清空会话 ID，下次保存时生成新 ID，会话数据保留
+func (s *ServerStore) Regenerate(_session *sessions.Session) error {
+    if len(_session.ID) > 0 {
+        if err := s.Handler.Destroy(_session.ID); err != nil {
+            return err
+        }
+    }
+    _session.ID = ""
+    return nil
+}
+
+// collectGarbage 按概率清理过期会话，避免每次请求都扫描存储
+func (s *ServerStore) collectGarbage(now time.Time) {
+    if s.GCLottery <= 0 {
+        return
+    }
+    n, err := rand.Int(rand.Reader, big.NewInt(s.GCLottery))
+    if err != nil || n.Int64() != 0 {
+        return
+    }
+    logger.LogError(s.Handler.GC(now))
+}
+
+// userID 会话所属用户，pkg/auth 登录时将用户 ID 写入 uid
+func userID(_session *sessions.Session) string {
+    uid, _ := _session.Values["uid"].(string)
+    return uid
+}
+
+// newSessionID 生成随机的会话 ID
+func newSessionID() string {
+    return strings.TrimRight(
+        base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+}
+
+func encodeValues(values map[interface{}]interface{}) ([]byte, error) {
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+func decodeValues(payload []byte, values *map[interface{}]interface{}) error {
+    if len(payload) == 0 {
+        return nil
+    }
+    return gob.NewDecoder(bytes.NewReader(payload)).Decode(values)
+}
+
+func truncate(s string, length int) string {
+    if len(s) > length {
+        return s[:length]
+    }
+    return s
+}
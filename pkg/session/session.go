@@ -1,66 +1,144 @@
 package session
 
 import (
+	"context"
 	"goblog/pkg/config"
 	"goblog/pkg/logger"
 	"net/http"
+	"sync"
 
+	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
 )
 
-// Store gorilla sessions 的存储库
-var Store = sessions.NewCookieStore([]byte(config.GetString("app.key")))
+// Store 会话存储驱动，首次使用时根据 session.default 配置创建
+var Store Driver
 
-// Session 当前会话
-var Session *sessions.Session
+var storeOnce sync.Once
 
-// Request 用以获取会话
-var Request *http.Request
+// getStore 获取会话存储驱动
+func getStore() Driver {
+    storeOnce.Do(func() {
+        if Store != nil {
+            return
+        }
+        driver, err := NewDriver(config.GetString("session.default"))
+        if err != nil {
+            // 驱动配置错误时退回 Cookie 驱动，保证站点可用
+            logger.LogError(err)
+            driver, _ = NewDriver("cookie")
+        }
+        Store = driver
+    })
+    return Store
+}
+
+// Session 单次请求的会话，随请求的 Context 传递
+type Session struct {
+    // Session gorilla 会话实例
+    Session *sessions.Session
 
-// Response 用以写入会话
-var Response http.ResponseWriter
+    // Request 用以获取会话
+    Request *http.Request
+
+    // Response 用以写入会话
+    Response http.ResponseWriter
+}
 
-// StartSession 初始化会话，在中间件中调用
-func StartSession(w http.ResponseWriter, r *http.Request) {
-    var err error
+// contextKey 会话在 Context 中的键名，使用私有类型避免与其他包冲突
+type contextKey struct{}
 
+// StartSession 初始化会话，在中间件中调用，返回携带会话的请求
+func StartSession(w http.ResponseWriter, r *http.Request) *http.Request {
     // Store.Get() 的第二个参数是 Cookie 的名称
     // gorilla/sessions 支持多会话，本项目我们只使用单一会话即可
-    Session, err = Store.Get(r, config.GetString("session.session_name"))
+    _session, err := getStore().Get(r, config.GetString("session.session_name"))
+    if e, ok := err.(securecookie.Error); ok && e.IsDecode() {
+        // Cookie 已过期或签名无效，gorilla 会返回一个新会话，无需中断请求
+        err = nil
+    }
     logger.LogError(err)
 
-    Request = r
-    Response = w
+    s := &Session{
+        Session:  _session,
+        Response: w,
+    }
+    r = r.WithContext(context.WithValue(r.Context(), contextKey{}, s))
+    s.Request = r
+
+    return r
+}
+
+// FromRequest 获取请求中的会话，未开启会话时返回 nil
+func FromRequest(r *http.Request) *Session {
+    s, _ := r.Context().Value(contextKey{}).(*Session)
+    return s
 }
 
 // Put 写入键值对应的会话数据
-func Put(key string, value interface{}) {
-    Session.Values[key] = value
-    Save()
+func Put(r *http.Request, key string, value interface{}) {
+    s := FromRequest(r)
+    if s == nil {
+        return
+    }
+    s.Session.Values[key] = value
+    s.Save()
 }
 
 // Get 获取会话数据，获取数据时请做类型检测
-func Get(key string) interface{} {
-    return Session.Values[key]
+func Get(r *http.Request, key string) interface{} {
+    s := FromRequest(r)
+    if s == nil {
+        return nil
+    }
+    return s.Session.Values[key]
 }
 
 // Forget 删除某个会话项
-func Forget(key string) {
-    delete(Session.Values, key)
-    Save()
+func Forget(r *http.Request, key string) {
+    s := FromRequest(r)
+    if s == nil {
+        return
+    }
+    delete(s.Session.Values, key)
+    s.Save()
+}
+
+// Regenerate 更换会话 ID 并保留会话数据，登录时调用，避免预先植入的会话 ID 成为已登录的会话。
+// 服务端驱动会删除旧会话；Cookie 驱动的数据本身就在 Cookie 中，重新保存即可
+func Regenerate(r *http.Request) {
+    s := FromRequest(r)
+    if s == nil {
+        return
+    }
+    if store, ok := s.Session.Store().(*ServerStore); ok {
+        logger.LogError(store.Regenerate(s.Session))
+    }
+    s.Save()
 }
 
 // Flush 删除当前会话
-func Flush() {
-    Session.Options.MaxAge = -1
-    Save()
+func Flush(r *http.Request) {
+    s := FromRequest(r)
+    if s == nil {
+        return
+    }
+    s.Session.Options.MaxAge = -1
+    s.Save()
+}
+
+// Save 保持会话
+func Save(r *http.Request) {
+    if s := FromRequest(r); s != nil {
+        s.Save()
+    }
 }
 
 // Save 保持会话
-func Save() {
+func (s *Session) Save() {
     // 非 HTTPS 的链接无法使用 Secure 和 HttpOnly，浏览器会报错
-    // Session.Options.Secure = true
-    // Session.Options.HttpOnly = true
-    err := Session.Save(Request, Response)
+    // s.Session.Options.Secure = true
+    // s.Session.Options.HttpOnly = true
+    err := s.Session.Save(s.Request, s.Response)
     logger.LogError(err)
-}
\ No newline at end of file
+}
@@ -0,0 +1,74 @@
+// Package sitemap 生成 sitemaps.org 协议的 XML 站点地图和站点地图索引
+package sitemap
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL 站点地图中的一个链接，LastMod 为零值时不输出
+type URL struct {
+    Loc     string
+    LastMod time.Time
+}
+
+// Sitemap 站点地图索引中的一个站点地图
+type Sitemap struct {
+    Loc     string
+    LastMod time.Time
+}
+
+type xmlURL struct {
+    Loc     string `xml:"loc"`
+    LastMod string `xml:"lastmod,omitempty"`
+}
+
+type urlSet struct {
+    XMLName xml.Name `xml:"urlset"`
+    Xmlns   string   `xml:"xmlns,attr"`
+    URLs    []xmlURL `xml:"url"`
+}
+
+type sitemapIndex struct {
+    XMLName  xml.Name `xml:"sitemapindex"`
+    Xmlns    string   `xml:"xmlns,attr"`
+    Sitemaps []xmlURL `xml:"sitemap"`
+}
+
+// WriteURLSet 输出站点地图
+func WriteURLSet(w io.Writer, urls []URL) error {
+    set := urlSet{Xmlns: xmlns, URLs: make([]xmlURL, len(urls))}
+    for i, u := range urls {
+        set.URLs[i] = xmlURL{Loc: u.Loc, LastMod: formatTime(u.LastMod)}
+    }
+    return write(w, set)
+}
+
+// WriteIndex 输出站点地图索引
+func WriteIndex(w io.Writer, sitemaps []Sitemap) error {
+    index := sitemapIndex{Xmlns: xmlns, Sitemaps: make([]xmlURL, len(sitemaps))}
+    for i, s := range sitemaps {
+        index.Sitemaps[i] = xmlURL{Loc: s.Loc, LastMod: formatTime(s.LastMod)}
+    }
+    return write(w, index)
+}
+
+func write(w io.Writer, v interface{}) error {
+    if _, err := io.WriteString(w, xml.Header); err != nil {
+        return err
+    }
+    enc := xml.NewEncoder(w)
+    enc.Indent("", "  ")
+    return enc.Encode(v)
+}
+
+// formatTime W3C Datetime 格式
+func formatTime(t time.Time) string {
+    if t.IsZero() {
+        return ""
+    }
+    return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,72 @@
+package throttle
+
+import (
+	"goblog/pkg/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DatabaseStore 数据库存储，记录保存在 throttles 表中，多个实例共享
+type DatabaseStore struct {
+}
+
+// NewDatabaseStore 创建数据库存储
+func NewDatabaseStore() *DatabaseStore {
+    return &DatabaseStore{}
+}
+
+// Get 获取未过期的记录，已过期的记录被删除
+func (*DatabaseStore) Get(key string) (Record, bool, error) {
+    var rec Record
+    err := model.DB.Where("`key` = ?", key).First(&rec).Error
+    if err == gorm.ErrRecordNotFound {
+        return Record{}, false, nil
+    }
+    if err != nil {
+        return Record{}, false, err
+    }
+    if !rec.ExpiresAt.After(time.Now()) {
+        return Record{}, false, model.DB.Delete(&rec).Error
+    }
+    return rec, true, nil
+}
+
+// Increment 以 upsert 累加次数，在同一事务中读回结果，并发的请求会等待行锁，不会互相覆盖。
+// MySQL 按顺序执行赋值，expires_at 需最后更新，前面的判断才能读到原值
+func (*DatabaseStore) Increment(key string, ttl time.Duration) (int, error) {
+    now := time.Now()
+    rec := Record{Key: key, Attempts: 1, LockedUntil: now, ExpiresAt: now.Add(ttl)}
+
+    err := model.DB.Transaction(func(tx *gorm.DB) error {
+        err := tx.Clauses(clause.OnConflict{
+            Columns: []clause.Column{{Name: "key"}},
+            DoUpdates: clause.Set{
+                {Column: clause.Column{Name: "attempts"}, Value: gorm.Expr("CASE WHEN throttles.expires_at > ? THEN throttles.attempts + 1 ELSE 1 END", now)},
+                {Column: clause.Column{Name: "locked_until"}, Value: gorm.Expr("CASE WHEN throttles.expires_at > ? THEN throttles.locked_until ELSE ? END", now, now)},
+                {Column: clause.Column{Name: "expires_at"}, Value: rec.ExpiresAt},
+            },
+        }).Create(&rec).Error
+        if err != nil {
+            return err
+        }
+        return tx.Where("`key` = ?", key).Take(&rec).Error
+    })
+    return rec.Attempts, err
+}
+
+// Lock 延长锁定时间，记录的过期时间不早于锁定时间
+func (*DatabaseStore) Lock(key string, until time.Time) error {
+    return model.DB.Model(&Record{}).
+        Where("`key` = ? AND locked_until < ?", key, until).
+        Updates(map[string]interface{}{
+            "locked_until": until,
+            "expires_at":   gorm.Expr("CASE WHEN expires_at < ? THEN ? ELSE expires_at END", until, until),
+        }).Error
+}
+
+// Forget 删除记录
+func (*DatabaseStore) Forget(key string) error {
+    return model.DB.Where("`key` = ?", key).Delete(&Record{}).Error
+}
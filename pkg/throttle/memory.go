@@ -0,0 +1,78 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore 进程内存储，重启后清空，多实例部署时各实例分别计数
+type MemoryStore struct {
+    mu      sync.Mutex
+    records map[string]Record
+}
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{records: make(map[string]Record)}
+}
+
+// Get 获取未过期的记录
+func (s *MemoryStore) Get(key string) (Record, bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    rec, ok := s.records[key]
+    if ok && !rec.ExpiresAt.After(time.Now()) {
+        delete(s.records, key)
+        return Record{}, false, nil
+    }
+    return rec, ok, nil
+}
+
+// Increment 在锁内累加次数，顺便清理已过期的记录
+func (s *MemoryStore) Increment(key string, ttl time.Duration) (int, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    now := time.Now()
+    for k, old := range s.records {
+        if !old.ExpiresAt.After(now) {
+            delete(s.records, k)
+        }
+    }
+
+    rec, ok := s.records[key]
+    if !ok {
+        rec = Record{Key: key}
+    }
+    rec.Attempts++
+    rec.ExpiresAt = now.Add(ttl)
+    s.records[key] = rec
+    return rec.Attempts, nil
+}
+
+// Lock 延长锁定时间，记录的过期时间不早于锁定时间
+func (s *MemoryStore) Lock(key string, until time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    rec, ok := s.records[key]
+    if !ok || !until.After(rec.LockedUntil) {
+        return nil
+    }
+    rec.LockedUntil = until
+    if until.After(rec.ExpiresAt) {
+        rec.ExpiresAt = until
+    }
+    s.records[key] = rec
+    return nil
+}
+
+// Forget 删除记录
+func (s *MemoryStore) Forget(key string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.records, key)
+    return nil
+}
@@ -0,0 +1,141 @@
+// Package throttle 失败次数限制，每次失败后按指数增长的间隔退避，达到上限后锁定一段时间。
+// 计数保存在可替换的存储中，memory 仅限单实例，database 可在多个实例间共享
+package throttle
+
+import (
+	"fmt"
+	"goblog/pkg/config"
+	"goblog/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Record 某个键的失败记录
+type Record struct {
+    Key         string    `gorm:"column:key;type:varchar(191);primaryKey"`
+    Attempts    int       `gorm:"column:attempts;not null;default:0"`
+    LockedUntil time.Time `gorm:"column:locked_until"`
+    ExpiresAt   time.Time `gorm:"column:expires_at;index"`
+}
+
+// TableName database 存储使用的数据表
+func (Record) TableName() string {
+    return "throttles"
+}
+
+// Store 失败记录的存储
+type Store interface {
+    // Get 获取未过期的记录，不存在或已过期时 ok 为 false
+    Get(key string) (rec Record, ok bool, err error)
+    // Increment 原子地将次数加一并返回累计次数，记录在 ttl 后过期，已过期的记录从一开始计数
+    Increment(key string, ttl time.Duration) (attempts int, err error)
+    // Lock 锁定到 until，已有更晚的锁定时间时不变
+    Lock(key string, until time.Time) error
+    // Forget 删除记录
+    Forget(key string) error
+}
+
+// Limiter 失败次数限制器
+type Limiter struct {
+    Store Store
+
+    // MaxAttempts 达到此失败次数后锁定
+    MaxAttempts int
+
+    // Backoff 首次失败后需等待的时间，此后每次失败翻倍，为 0 时不退避
+    Backoff time.Duration
+
+    // Lockout 锁定时长，也是记录在最后一次尝试后的保留时长
+    Lockout time.Duration
+}
+
+// Hit 原子地记录一次尝试，返回累计次数，以及本次尝试失败时需等待的时间。
+// 应在校验之前调用，累计次数超过 MaxAttempts 时本次尝试不应再校验，
+// 这样并发的尝试也会被逐一计数，无法同时绕过限制
+func (l *Limiter) Hit(key string) (attempts int, wait time.Duration, err error) {
+    attempts, err = l.Store.Increment(key, l.Lockout)
+    if err != nil {
+        return 0, 0, err
+    }
+
+    wait = l.delay(attempts)
+    if wait > 0 {
+        err = l.Store.Lock(key, time.Now().Add(wait))
+    }
+    return attempts, wait, err
+}
+
+// TooMany 累计次数是否已超过上限
+func (l *Limiter) TooMany(attempts int) bool {
+    return attempts > l.MaxAttempts
+}
+
+// AvailableIn 距离可以再次尝试的时间，为 0 表示现在即可尝试
+func (l *Limiter) AvailableIn(key string) (time.Duration, error) {
+    rec, ok, err := l.Store.Get(key)
+    if err != nil || !ok {
+        return 0, err
+    }
+    if wait := time.Until(rec.LockedUntil); wait > 0 {
+        return wait, nil
+    }
+    return 0, nil
+}
+
+// Clear 清除失败记录，在成功后调用
+func (l *Limiter) Clear(key string) error {
+    return l.Store.Forget(key)
+}
+
+// delay 第 attempts 次失败后需等待的时间
+func (l *Limiter) delay(attempts int) time.Duration {
+    if attempts >= l.MaxAttempts {
+        return l.Lockout
+    }
+    if l.Backoff <= 0 {
+        return 0
+    }
+    wait := l.Backoff << uint(attempts-1)
+    if wait > l.Lockout || wait <= 0 {
+        return l.Lockout
+    }
+    return wait
+}
+
+var (
+    store     Store
+    storeOnce sync.Once
+)
+
+// NewStore 根据名称创建存储
+func NewStore(name string) (Store, error) {
+    switch name {
+    case "memory":
+        return NewMemoryStore(), nil
+    case "database":
+        return NewDatabaseStore(), nil
+    }
+    return nil, fmt.Errorf("throttle: 不支持的存储 %q", name)
+}
+
+// DefaultStore 获取默认存储，首次使用时根据 throttle.store 配置创建
+func DefaultStore() Store {
+    storeOnce.Do(func() {
+        if store != nil {
+            return
+        }
+        s, err := NewStore(config.GetString("throttle.store"))
+        if err != nil {
+            logger.LogError(err)
+            s = NewMemoryStore()
+        }
+        store = s
+    })
+    return store
+}
+
+// SetStore 指定默认存储，用于测试
+func SetStore(s Store) {
+    storeOnce.Do(func() {})
+    store = s
+}
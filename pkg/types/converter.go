@@ -18,7 +18,19 @@ func Uint64ToString(num uint64) string {
 func StringToInt(str string) int{
 	i, err := strconv.Atoi(str)
 	if err != nil {
-		logger.LogError(err)
+		logger.LogWarn(err)
 	}
 	return i
-}
\ No newline at end of file
+}
+
+// StringToUint64 将 string 转换为 uint64，空字符串或转换失败时返回 0
+func StringToUint64(str string) uint64 {
+    if str == "" {
+        return 0
+    }
+    i, err := strconv.ParseUint(str, 10, 64)
+    if err != nil {
+        logger.LogWarn(err)
+    }
+    return i
+}
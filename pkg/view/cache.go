@@ -0,0 +1,232 @@
+package view
+
+import (
+	"fmt"
+	"goblog/app/models/category"
+	"goblog/app/models/tag"
+	"goblog/pkg/config"
+	"goblog/pkg/csrf"
+	"goblog/pkg/logger"
+	"goblog/pkg/route"
+	"goblog/pkg/search"
+	"goblog/policies"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// viewDir 模板相对路径
+const viewDir = "resources/views/"
+
+// cachedTemplate 解析后的模板集合，以及解析时模板文件的最后修改时间
+type cachedTemplate struct {
+    tmpl    *template.Template
+    modTime time.Time
+}
+
+var (
+    // templates 以模板文件列表为键缓存解析后的模板集合
+    templates = make(map[string]*cachedTemplate)
+    mu        sync.RWMutex
+
+    // sharedFiles 每个页面都会加载的布局和局部模板，调试模式下每次重新查找
+    sharedFiles   []string
+    sharedFilesMu sync.Mutex
+)
+
+// Compile 预先解析所有页面模板并缓存，在启动时调用，使模板错误在启动阶段暴露。
+// 页面模板为 resources/views 下除 layouts 目录和以 _ 开头的局部模板之外的所有文件
+func Compile() error {
+    shared, err := getSharedFiles()
+    if err != nil {
+        return err
+    }
+
+    var pages []string
+    err = filepath.Walk(viewDir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if info.IsDir() || filepath.Ext(path) != ".gohtml" || isSharedFile(path) {
+            return nil
+        }
+        pages = append(pages, filepath.ToSlash(path))
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    for _, page := range pages {
+        if _, err := loadTemplate(mergeFiles(shared, []string{page})); err != nil {
+            return fmt.Errorf("view: 解析 %s 失败：%w", page, err)
+        }
+    }
+    return nil
+}
+
+// getTemplate 获取模板集合，tplFiles 支持 dir.filename 语法糖，如 articles.show
+func getTemplate(tplFiles ...string) (*template.Template, error) {
+    shared, err := getSharedFiles()
+    if err != nil {
+        return nil, err
+    }
+
+    files := make([]string, len(tplFiles))
+    for i, f := range tplFiles {
+        files[i] = viewDir + strings.Replace(f, ".", "/", -1) + ".gohtml"
+    }
+
+    return loadTemplate(mergeFiles(shared, files))
+}
+
+// loadTemplate 优先从缓存读取，调试模式下模板文件有改动时重新解析
+func loadTemplate(files []string) (*template.Template, error) {
+    key := strings.Join(files, "|")
+
+    mu.RLock()
+    cached, ok := templates[key]
+    mu.RUnlock()
+
+    debug := config.GetBool("app.debug")
+    if ok && !debug {
+        return cached.tmpl, nil
+    }
+
+    modTime, err := latestModTime(files)
+    if err != nil {
+        return nil, err
+    }
+    if ok && !modTime.After(cached.modTime) {
+        return cached.tmpl, nil
+    }
+
+    tmpl, err := template.New("").Funcs(funcMap()).ParseFiles(files...)
+    if err != nil {
+        return nil, err
+    }
+
+    mu.Lock()
+    templates[key] = &cachedTemplate{tmpl: tmpl, modTime: modTime}
+    mu.Unlock()
+
+    return tmpl, nil
+}
+
+// funcMap 模板中可使用的方法
+func funcMap() template.FuncMap {
+    return template.FuncMap{
+        "RouteName2URL":     route.RouteName2URL,
+        "SidebarCategories": sidebarCategories,
+        "TagCloud":          tagCloud,
+        "Highlight":         search.Highlight,
+
+        // 以下方法与当前请求相关，解析时仅作占位，渲染时由 withRequestFuncs 替换
+        "CSRFField": func() template.HTML { return "" },
+        "CSRFToken": func() string { return "" },
+        "Can":       func(ability string) bool { return false },
+    }
+}
+
+// withRequestFuncs 复制缓存的模板集合并绑定与当前请求相关的模板方法，缓存中的模板始终不被执行
+func withRequestFuncs(tmpl *template.Template, r *http.Request, csrfToken string) (*template.Template, error) {
+    clone, err := tmpl.Clone()
+    if err != nil {
+        return nil, err
+    }
+    return clone.Funcs(template.FuncMap{
+        "CSRFField": func() template.HTML { return csrf.Field(csrfToken) },
+        "CSRFToken": func() string { return csrfToken },
+        "Can":       func(ability string) bool { return policies.Allows(r, ability, nil) },
+    }), nil
+}
+
+// sidebarCategories 侧边栏的分类列表，只在模板调用时查询，查询失败时不影响页面显示
+func sidebarCategories() []category.Category {
+    categories, err := category.AllWithArticlesCount()
+    logger.LogError(err)
+    return categories
+}
+
+// tagCloud 侧边栏的标签云，取使用最多的 30 个标签
+func tagCloud() []tag.CloudTag {
+    tags, err := tag.Cloud(30)
+    logger.LogError(err)
+    return tags
+}
+
+// getSharedFiles 所有布局模板 layouts/*.gohtml，以及各目录下以 _ 开头的局部模板
+func getSharedFiles() ([]string, error) {
+    if config.GetBool("app.debug") {
+        // 调试模式下可能新增模板文件，每次重新查找
+        return findSharedFiles()
+    }
+
+    // 查找失败时不缓存，下次调用重试
+    sharedFilesMu.Lock()
+    defer sharedFilesMu.Unlock()
+    if sharedFiles == nil {
+        files, err := findSharedFiles()
+        if err != nil {
+            return nil, err
+        }
+        sharedFiles = files
+    }
+    return sharedFiles, nil
+}
+
+func findSharedFiles() ([]string, error) {
+    var files []string
+    err := filepath.Walk(viewDir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if !info.IsDir() && filepath.Ext(path) == ".gohtml" && isSharedFile(path) {
+            files = append(files, filepath.ToSlash(path))
+        }
+        return nil
+    })
+    return files, err
+}
+
+// isSharedFile 是否布局或局部模板
+func isSharedFile(path string) bool {
+    path = filepath.ToSlash(path)
+    return strings.HasPrefix(path, viewDir+"layouts/") || strings.HasPrefix(filepath.Base(path), "_")
+}
+
+// mergeFiles 合并文件列表并去重排序，相同的文件组合对应同一个缓存
+func mergeFiles(lists ...[]string) []string {
+    seen := make(map[string]bool)
+    var files []string
+    for _, list := range lists {
+        for _, f := range list {
+            if !seen[f] {
+                seen[f] = true
+                files = append(files, f)
+            }
+        }
+    }
+    sort.Strings(files)
+    return files
+}
+
+// latestModTime 文件列表中最新的修改时间
+func latestModTime(files []string) (time.Time, error) {
+    var latest time.Time
+    for _, f := range files {
+        info, err := os.Stat(f)
+        if err != nil {
+            return latest, err
+        }
+        if info.ModTime().After(latest) {
+            latest = info.ModTime()
+        }
+    }
+    return latest, nil
+}
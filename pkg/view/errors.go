@@ -0,0 +1,46 @@
+package view
+
+import (
+	"bytes"
+	"goblog/pkg/response"
+	"net/http"
+	"strconv"
+)
+
+// errorMessages 各状态码的默认提示信息
+var errorMessages = map[int]string{
+    http.StatusForbidden:           "您没有权限执行此操作",
+    http.StatusNotFound:            "您访问的页面不存在",
+    419:                            "页面已过期，请刷新后重试",
+    http.StatusInternalServerError: "服务器内部错误，请稍后再试",
+}
+
+// RenderError 渲染错误页面，支持 403/404/419/500，message 为空时使用默认提示。
+// 请求标头 Accept 为 application/json 时返回 JSON 格式的错误信息
+func RenderError(w http.ResponseWriter, r *http.Request, status int, message string) {
+    if len(message) == 0 {
+        message = errorMessages[status]
+    }
+
+    if response.WantsJSON(r) {
+        response.Error(w, status, message)
+        return
+    }
+
+    // 没有对应模板的状态码使用 500 页面
+    tplFile := "errors.500"
+    if _, ok := errorMessages[status]; ok {
+        tplFile = "errors." + strconv.Itoa(status)
+    }
+
+    // 先渲染到缓冲区，保证会话 Cookie 等标头在写入状态码之前设置
+    var buf bytes.Buffer
+    Render(&buf, r, D{
+        "Code":    status,
+        "Message": message,
+    }, tplFile)
+
+    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+    w.WriteHeader(status)
+    buf.WriteTo(w)
+}
@@ -1,62 +1,53 @@
 package view
 
 import (
+	"goblog/app/models/user"
 	"goblog/pkg/auth"
+	"goblog/pkg/csrf"
 	"goblog/pkg/flash"
 	"goblog/pkg/logger"
-	"goblog/pkg/route"
-	"html/template"
 	"io"
-	"path/filepath"
-	"strings"
+	"net/http"
+
+	"go.uber.org/zap"
 )
 
 // D 是 map[string]interface{} 的简写
 type D map[string]interface{}
 
 // Render 渲染通用视图
-func Render(w io.Writer, data D, tplFiles ...string) {
-    RenderTemplate(w, "app", data, tplFiles...)
+func Render(w io.Writer, r *http.Request, data D, tplFiles ...string) {
+    RenderTemplate(w, r, "app", data, tplFiles...)
 }
 
 // RenderSimple 渲染简单的视图
-func RenderSimple(w io.Writer, data D, tplFiles ...string) {
-    RenderTemplate(w, "simple", data, tplFiles...)
+func RenderSimple(w io.Writer, r *http.Request, data D, tplFiles ...string) {
+    RenderTemplate(w, r, "simple", data, tplFiles...)
 }
 
-func RenderTemplate(w io.Writer, name string, data D, tplFiles ...string) {
+// RenderTemplate 渲染视图，会话相关的通用数据均从当前请求中读取
+func RenderTemplate(w io.Writer, r *http.Request, name string, data D, tplFiles ...string) {
     // 1. 通用模板数据
-    data["isLogined"] = auth.Check()
-    data["loginUser"] = auth.User
-    data["flash"] = flash.All()
-
-    // 2. 生成模板文件
-    allFiles := getTemplateFiles(tplFiles...)
-
-    // 解析所有模板文件
-    tmpl, err := template.New("").
-        Funcs(template.FuncMap{
-            "RouteName2URL": route.RouteName2URL,
-        }).ParseFiles(allFiles...)
-    logger.LogError(err)
-
-    // 渲染模板
-    tmpl.ExecuteTemplate(w, name, data)
-}
-
-func getTemplateFiles(tplFiles ...string) []string {
-    // 1 设置模板相对路径
-    viewDir := "resources/views/"
-
-    // 2. 遍历传参文件列表 Slice，设置正确的路径，支持 dir.filename 语法糖
-    for i, f := range tplFiles {
-        tplFiles[i] = viewDir + strings.Replace(f, ".", "/", -1) + ".gohtml"
+    data["isLogined"] = auth.Check(r)
+    data["loginUser"] = func() user.User {
+        return auth.User(r)
+    }
+    data["flash"] = flash.All(r)
+    // 在输出内容前生成 CSRF 令牌，保证会话 Cookie 能写入响应头
+    csrfToken := csrf.Token(r)
+
+    // 2. 从缓存中获取解析好的模板，绑定当前请求的模板方法
+    tmpl, err := getTemplate(tplFiles...)
+    if err == nil {
+        tmpl, err = withRequestFuncs(tmpl, r, csrfToken)
+    }
+    if err != nil {
+        logger.WithContext(r.Context()).Error("模板解析失败", zap.Strings("templates", tplFiles), zap.Error(err))
+        return
     }
 
-    // 3. 所有布局模板文件 Slice
-    layoutFiles, err := filepath.Glob(viewDir + "layouts/*.gohtml")
-    logger.LogError(err)
-
-    // 4. 合并所有文件
-    return append(layoutFiles, tplFiles...)
-}
\ No newline at end of file
+    // 3. 渲染模板
+    if err := tmpl.ExecuteTemplate(w, name, data); err != nil {
+        logger.WithContext(r.Context()).Error("模板渲染失败", zap.String("template", name), zap.Error(err))
+    }
+}
@@ -0,0 +1,16 @@
+package policies
+
+import (
+    "goblog/app/models/article"
+    "goblog/app/models/role"
+    "net/http"
+)
+
+func init() {
+    Define(role.PermissionModerateComments, isArticleAuthor)
+}
+
+// CanModerateComments 是否允许审核和删除文章下的评论，文章作者、管理员和编辑可以
+func CanModerateComments(r *http.Request, _article article.Article) bool {
+    return Allows(r, role.PermissionModerateComments, _article)
+}
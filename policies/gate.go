@@ -0,0 +1,94 @@
+// Package policies 授权规则，通过 Can 判断用户是否具备某项能力
+package policies
+
+import (
+    "context"
+    "goblog/app/models/role"
+    "goblog/app/models/user"
+    "goblog/pkg/auth"
+    "net/http"
+    "sync"
+)
+
+// Policy 针对具体资源的授权规则，在用户没有对应权限时判断，例如作者可以修改自己的文章
+type Policy func(_user user.User, resource interface{}) bool
+
+// rules 能力名称与授权规则的对应关系
+var rules = map[string]Policy{}
+
+// Define 定义能力针对具体资源的授权规则，通常在 init 中调用
+func Define(ability string, policy Policy) {
+    rules[ability] = policy
+}
+
+// Can 用户是否具备 ability 能力，resource 为操作的资源，不针对具体资源时传 nil。
+// 每次调用都会读取用户的角色和权限，处理请求时应使用 Allows
+func Can(_user user.User, ability string, resource interface{}) bool {
+    if _user.ID == 0 {
+        return false
+    }
+    grants, err := role.GrantsOf(_user.ID)
+    if err != nil {
+        return false
+    }
+    return check(_user, grants, ability, resource)
+}
+
+// Allows 当前登录用户是否具备 ability 能力，角色和权限在同一请求内只读取一次
+func Allows(r *http.Request, ability string, resource interface{}) bool {
+    _user := auth.User(r)
+    if _user.ID == 0 {
+        return false
+    }
+    grants, err := requestGrants(r, _user.ID)
+    if err != nil {
+        return false
+    }
+    return check(_user, grants, ability, resource)
+}
+
+// check 游客不具备任何能力；管理员具备全部能力；其余用户拥有同名权限，或满足该能力的授权规则即可。
+// 读取角色出错时 Can 和 Allows 一律拒绝
+func check(_user user.User, grants role.Grants, ability string, resource interface{}) bool {
+    if grants.Roles[role.Admin] || grants.Permissions[ability] {
+        return true
+    }
+    if policy, ok := rules[ability]; ok && resource != nil {
+        return policy(_user, resource)
+    }
+    return false
+}
+
+// grantsCache 单次请求内缓存的角色和权限，登录用户在请求中变化时重新读取
+type grantsCache struct {
+    mu     sync.Mutex
+    uid    uint64
+    grants role.Grants
+}
+
+// contextKey 缓存在请求上下文中的键
+type contextKey struct{}
+
+// WithCache 为请求开启角色和权限的缓存，在中间件中调用
+func WithCache(r *http.Request) *http.Request {
+    return r.WithContext(context.WithValue(r.Context(), contextKey{}, &grantsCache{}))
+}
+
+// requestGrants 读取用户的角色和权限，请求未开启缓存时每次读取
+func requestGrants(r *http.Request, uid uint64) (role.Grants, error) {
+    cache, ok := r.Context().Value(contextKey{}).(*grantsCache)
+    if !ok {
+        return role.GrantsOf(uid)
+    }
+
+    cache.mu.Lock()
+    defer cache.mu.Unlock()
+    if cache.uid != uid {
+        grants, err := role.GrantsOf(uid)
+        if err != nil {
+            return grants, err
+        }
+        cache.uid, cache.grants = uid, grants
+    }
+    return cache.grants, nil
+}
@@ -2,10 +2,28 @@ package policies
 
 import (
     "goblog/app/models/article"
-    "goblog/pkg/auth"
+    "goblog/app/models/role"
+    "goblog/app/models/user"
+    "net/http"
 )
 
-// CanModifyArticle 是否允许修改话题
-func CanModifyArticle(_article article.Article) bool {
-    return auth.User().ID == _article.UserID
-}
\ No newline at end of file
+func init() {
+    Define(role.PermissionUpdateArticles, isArticleAuthor)
+    Define(role.PermissionDeleteArticles, isArticleAuthor)
+}
+
+// isArticleAuthor 作者可以修改和删除自己的文章
+func isArticleAuthor(_user user.User, resource interface{}) bool {
+    _article, ok := resource.(article.Article)
+    return ok && _article.UserID == _user.ID
+}
+
+// CanModifyArticle 是否允许修改话题，作者本人、管理员和编辑可以
+func CanModifyArticle(r *http.Request, _article article.Article) bool {
+    return Allows(r, role.PermissionUpdateArticles, _article)
+}
+
+// CanDeleteArticle 是否允许删除话题，作者本人、管理员和编辑可以
+func CanDeleteArticle(r *http.Request, _article article.Article) bool {
+    return Allows(r, role.PermissionDeleteArticles, _article)
+}
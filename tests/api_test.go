@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"encoding/json"
+	"goblog/app/models/article"
+	"goblog/app/models/role"
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// apiClient 携带会话 Cookie 和 CSRF 令牌请求接口
+type apiClient struct {
+	router    *mux.Router
+	cookies   []*http.Cookie
+	csrfToken string
+}
+
+var csrfMeta = regexp.MustCompile(`<meta name="csrf-token" content="([^"]+)">`)
+
+func (c *apiClient) do(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if method == "POST" && !strings.HasPrefix(path, "/api/") {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method != "GET" && c.csrfToken != "" {
+		req.Header.Set("X-CSRF-Token", c.csrfToken)
+	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	c.router.ServeHTTP(rec, req)
+	// 按名称合并 Cookie，MaxAge 小于 0 的被删除
+	for _, cookie := range rec.Result().Cookies() {
+		kept := c.cookies[:0]
+		for _, old := range c.cookies {
+			if old.Name != cookie.Name {
+				kept = append(kept, old)
+			}
+		}
+		c.cookies = kept
+		if cookie.MaxAge >= 0 {
+			c.cookies = append(c.cookies, cookie)
+		}
+	}
+	return rec
+}
+
+// createVerifiedUser 创建邮箱已验证的用户，密码为 secret
+func createVerifiedUser(t *testing.T, name string, email string) user.User {
+	verifiedAt := time.Now()
+	_user := user.User{Name: name, Email: email, Password: "secret", EmailVerifiedAt: &verifiedAt}
+	assert.NoError(t, _user.Create())
+	assert.NoError(t, role.AssignDefault(_user.ID))
+	return _user
+}
+
+func (c *apiClient) login(email string) {
+	if m := csrfMeta.FindStringSubmatch(c.do("GET", "/auth/login", "").Body.String()); m != nil {
+		c.csrfToken = m[1]
+	}
+	c.do("POST", "/auth/dologin", url.Values{"email": {email}, "password": {"secret"}}.Encode())
+}
+
+func TestArticlesAPI(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	router := bootstrap.SetupRoute()
+
+	author := createVerifiedUser(t, "summer", "summer@example.com")
+	createVerifiedUser(t, "winter", "winter@example.com")
+	_article := article.Article{Title: "API title", Body: "API body long enough", UserID: author.ID, TagNames: "go"}
+	assert.NoError(t, _article.Create())
+	id := _article.GetStringID()
+
+	guest := &apiClient{router: router}
+	rec := guest.do("GET", "/api/v1/articles", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+		Meta map[string]interface{}   `json:"meta"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.EqualValues(t, 1, list.Meta["total_count"])
+	assert.NotContains(t, rec.Body.String(), "summer@example.com", "不应暴露作者邮箱")
+
+	assert.Equal(t, http.StatusNotFound, guest.do("GET", "/api/v1/articles/99", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, guest.do("POST", "/api/v1/articles", `{}`).Code)
+
+	// 作者创建文章，验证失败返回 422 和各字段错误
+	owner := &apiClient{router: router}
+	owner.login("summer@example.com")
+	rec = owner.do("POST", "/api/v1/articles", `{"title":"a","body":"short"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var invalid struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &invalid))
+	assert.Contains(t, invalid.Errors, "title")
+	assert.Contains(t, invalid.Errors, "body")
+	assert.Equal(t, http.StatusBadRequest, owner.do("POST", "/api/v1/articles", `{`).Code)
+
+	rec = owner.do("POST", "/api/v1/articles", `{"title":"New API title","body":"A body long enough","tags":["go","api"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"summer"`)
+
+	// 部分更新保留未提供的字段和标签
+	rec = owner.do("PATCH", "/api/v1/articles/"+id, `{"title":"Patched title"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	updated, _ := article.Get(id)
+	assert.Equal(t, "Patched title", updated.Title)
+	assert.Equal(t, "API body long enough", updated.Body)
+	assert.Equal(t, "go", updated.TagList())
+
+	// 其他用户无权修改和删除
+	stranger := &apiClient{router: router}
+	stranger.login("winter@example.com")
+	assert.Equal(t, http.StatusForbidden, stranger.do("PUT", "/api/v1/articles/"+id, `{"title":"Hijacked"}`).Code)
+	assert.Equal(t, http.StatusForbidden, stranger.do("DELETE", "/api/v1/articles/"+id, "").Code)
+
+	assert.Equal(t, http.StatusNoContent, owner.do("DELETE", "/api/v1/articles/"+id, "").Code)
+	assert.Equal(t, http.StatusNotFound, guest.do("GET", "/api/v1/articles/"+id, "").Code)
+
+	rec = guest.do("GET", "/api/v1/users/"+author.GetStringID(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "password")
+	assert.Equal(t, http.StatusNotFound, guest.do("GET", "/api/v1/users/99", "").Code)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/category"
+	"goblog/app/requests"
+	"goblog/bootstrap"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryArticlesCount(t *testing.T) {
+	setupSQLite(t)
+
+	tpl := category.Category{Name: "模板"}
+	db := category.Category{Name: "数据库"}
+	assert.NoError(t, tpl.Create())
+	assert.NoError(t, db.Create())
+
+	for i := 0; i < 3; i++ {
+		_article := article.Article{Title: "title", Body: "body", UserID: 1, CategoryID: tpl.ID}
+		assert.NoError(t, _article.Create())
+	}
+
+	categories, err := category.AllWithArticlesCount()
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+	assert.Equal(t, int64(3), categories[0].ArticlesCount)
+	assert.Equal(t, int64(0), categories[1].ArticlesCount)
+
+	bootstrap.SetupRoute()
+	r := httptest.NewRequest("GET", "/categories/"+tpl.GetStringID(), nil)
+	articles, pagerData, err := article.GetByCategoryID(r, tpl.GetStringID(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.Equal(t, int64(3), pagerData.TotalCount)
+	assert.Equal(t, "模板", articles[0].Category.Name)
+}
+
+func TestArticleFormCategoryExists(t *testing.T) {
+	setupSQLite(t)
+
+	_category := category.Category{Name: "模板"}
+	assert.NoError(t, _category.Create())
+
+	valid := article.Article{Title: "hello", Body: "some long body", CategoryID: _category.ID}
+	assert.NotContains(t, requests.ValidateArticleForm(valid), "category_id")
+
+	// 0 表示未分类，允许提交
+	valid.CategoryID = 0
+	assert.NotContains(t, requests.ValidateArticleForm(valid), "category_id")
+
+	invalid := article.Article{Title: "hello", Body: "some long body", CategoryID: 99}
+	assert.Contains(t, requests.ValidateArticleForm(invalid), "category_id")
+}
+
+func TestArticleUpdateCategory(t *testing.T) {
+	setupSQLite(t)
+
+	tpl := category.Category{Name: "模板"}
+	db := category.Category{Name: "数据库"}
+	assert.NoError(t, tpl.Create())
+	assert.NoError(t, db.Create())
+
+	_article := article.Article{Title: "title", Body: "body", UserID: 1, CategoryID: tpl.ID}
+	assert.NoError(t, _article.Create())
+
+	// 通过 Get 加载会预加载 Category，修改分类后不能被旧的关联覆盖
+	loaded, err := article.Get(_article.GetStringID())
+	assert.NoError(t, err)
+	loaded.CategoryID = db.ID
+	_, err = loaded.Update()
+	assert.NoError(t, err)
+
+	loaded, err = article.Get(_article.GetStringID())
+	assert.NoError(t, err)
+	assert.Equal(t, db.ID, loaded.CategoryID)
+
+	// 改回未分类
+	loaded.CategoryID = 0
+	_, err = loaded.Update()
+	assert.NoError(t, err)
+
+	loaded, err = article.Get(_article.GetStringID())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), loaded.CategoryID)
+}
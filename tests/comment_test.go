@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/comment"
+	"goblog/app/requests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentTreeAndModeration(t *testing.T) {
+	setupSQLite(t)
+
+	_article := article.Article{Title: "title", Body: "body", UserID: 1}
+	assert.NoError(t, _article.Create())
+
+	create := func(userID, parentID uint64, status string) comment.Comment {
+		_comment := comment.Comment{ArticleID: _article.ID, UserID: userID, ParentID: parentID, Body: "comment", Status: status}
+		assert.NoError(t, _comment.Create())
+		return _comment
+	}
+	root := create(2, 0, comment.StatusApproved)
+	reply := create(1, root.ID, comment.StatusApproved)
+	create(3, reply.ID, comment.StatusApproved)
+	pending := create(2, 0, comment.StatusPending)
+	spam := create(3, 0, comment.StatusSpam)
+	create(2, spam.ID, comment.StatusApproved)
+
+	// 读者只能看到已通过的评论和自己的待审核评论，垃圾评论下的回复不显示
+	comments, err := comment.ForArticle(_article.ID, 3, false)
+	assert.NoError(t, err)
+	tree := comment.Tree(comments)
+	assert.Len(t, tree, 1)
+	assert.Len(t, tree[0].Replies, 1)
+	assert.Len(t, tree[0].Replies[0].Replies, 1)
+
+	comments, err = comment.ForArticle(_article.ID, 2, false)
+	assert.NoError(t, err)
+	assert.Len(t, comment.Tree(comments), 2)
+
+	// 文章作者可以看到全部评论
+	comments, err = comment.ForArticle(_article.ID, 1, true)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 6)
+
+	assert.NoError(t, pending.SetStatus(comment.StatusApproved))
+	pending, _ = comment.Get(pending.GetStringID())
+	assert.False(t, pending.IsPending())
+
+	// 删除评论时一并删除回复
+	rowsAffected, err := root.Delete()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), rowsAffected)
+
+	// 删除文章时删除全部评论
+	_, err = _article.Delete()
+	assert.NoError(t, err)
+	comments, _ = comment.ForArticle(_article.ID, 1, true)
+	assert.Empty(t, comments)
+}
+
+func TestCommentFormValidatesParent(t *testing.T) {
+	setupSQLite(t)
+
+	first := article.Article{Title: "first", Body: "body", UserID: 1}
+	second := article.Article{Title: "second", Body: "body", UserID: 1}
+	assert.NoError(t, first.Create())
+	assert.NoError(t, second.Create())
+
+	parent := comment.Comment{ArticleID: first.ID, UserID: 1, Body: "parent", Status: comment.StatusApproved}
+	assert.NoError(t, parent.Create())
+
+	assert.Empty(t, requests.ValidateCommentForm(comment.Comment{ArticleID: first.ID, ParentID: parent.ID, Body: "reply"}))
+	assert.Contains(t, requests.ValidateCommentForm(comment.Comment{ArticleID: first.ID, Body: ""}), "body")
+
+	// 不能回复其他文章或不存在的评论
+	assert.Contains(t, requests.ValidateCommentForm(comment.Comment{ArticleID: second.ID, ParentID: parent.ID, Body: "reply"}), "parent_id")
+	assert.Contains(t, requests.ValidateCommentForm(comment.Comment{ArticleID: first.ID, ParentID: 99, Body: "reply"}), "parent_id")
+}
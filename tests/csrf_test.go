@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"goblog/bootstrap"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRFProtection(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	router := bootstrap.SetupRoute()
+
+	createVerifiedUser(t, "summer", "summer@example.com")
+
+	// 未获取令牌的登录请求被拒绝
+	attacker := &apiClient{router: router}
+	form := url.Values{"email": {"summer@example.com"}, "password": {"secret"}}.Encode()
+	assert.Equal(t, 419, attacker.do("POST", "/auth/dologin", form).Code)
+
+	client := &apiClient{router: router}
+	client.login("summer@example.com")
+	assert.NotEmpty(t, client.csrfToken)
+
+	// 表单中的隐藏字段
+	body := client.do("GET", "/articles/create", "").Body.String()
+	assert.Contains(t, body, `<input type="hidden" name="_token" value="`+client.csrfToken+`">`)
+
+	// 令牌错误时显示 419 页面，JSON 请求返回 JSON
+	valid := client.csrfToken
+	client.csrfToken = "forged"
+	rec := client.do("POST", "/auth/logout", "")
+	assert.Equal(t, 419, rec.Code)
+	assert.Contains(t, rec.Body.String(), "页面已过期")
+	rec = client.do("POST", "/api/v1/articles", `{"title":"Forged title","body":"Forged body content"}`)
+	assert.Equal(t, 419, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+	// 表单字段中的令牌同样有效
+	client.csrfToken = ""
+	rec = client.do("POST", "/auth/logout", url.Values{"_token": {valid}}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"goblog/app/models/user"
+	"goblog/app/requests"
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"goblog/pkg/migrate"
+	"strings"
+	"testing"
+
+	// 注册所有迁移文件
+	_ "goblog/database/migrations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupSQLite 使用 SQLite 内存数据库初始化数据库并执行迁移，测试无需 MySQL 服务
+func setupSQLite(t *testing.T) {
+	c.Viper.Set("database.connection", "sqlite")
+	c.Viper.Set("database.sqlite.database", ":memory:")
+	bootstrap.SetUpDB()
+
+	migrator, err := migrate.NewMigrator()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = migrator.Up()
+	assert.NoError(t, err)
+}
+
+func TestSQLiteConnection(t *testing.T) {
+	setupSQLite(t)
+
+	_user := user.User{
+		Name:     "summer",
+		Email:    "summer@example.com",
+		Password: "$2a$14$" + strings.Repeat("a", 53),
+	}
+	assert.NoError(t, _user.Create())
+	assert.NotZero(t, _user.ID)
+
+	// not_exists 规则在 SQLite 下同样生效
+	errs := requests.ValidateRegistrationForm(user.User{
+		Name:            "summer",
+		Email:           "summer@example.com",
+		Password:        "secret",
+		PasswordConfirm: "secret",
+	})
+	assert.Contains(t, errs, "name")
+	assert.Contains(t, errs, "email")
+}
+
+func TestMigrateRollbackAndStatus(t *testing.T) {
+	setupSQLite(t)
+	migrator, err := migrate.NewMigrator()
+	assert.NoError(t, err)
+
+	statuses, err := migrator.Status()
+	assert.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Ran, status.Migration)
+	}
+
+	// 回滚全部后数据表被删除，再次迁移可以恢复
+	rolledBack, err := migrator.Reset()
+	assert.NoError(t, err)
+	assert.Len(t, rolledBack, len(statuses))
+	assert.False(t, migrator.DB.Migrator().HasTable("users"))
+
+	migrated, err := migrator.Up()
+	assert.NoError(t, err)
+	assert.Len(t, migrated, len(statuses))
+	assert.True(t, migrator.DB.Migrator().HasTable("users"))
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"encoding/json"
+	"goblog/app/models/article"
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeds(t *testing.T) {
+	setupSQLite(t)
+	c.Viper.Set("app.url", "https://blog.example.com/")
+	router := bootstrap.SetupRoute()
+
+	_user := user.User{Name: "summer", Email: "summer@example.com", Password: "$2a$14$" + strings.Repeat("a", 53)}
+	assert.NoError(t, _user.Create())
+	_article := article.Article{Title: "Feed title", Body: "Feed **body**", UserID: _user.ID}
+	assert.NoError(t, _article.Create())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	rec := get("/feed.xml")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/rss+xml")
+	assert.Contains(t, rec.Body.String(), "<link>https://blog.example.com/articles/"+_article.GetStringID()+"</link>")
+
+	// Atom 的 updated 取文章的 UpdatedAt
+	rec = get("/atom.xml")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/atom+xml")
+	assert.Contains(t, rec.Body.String(), "<updated>"+_article.UpdatedAt.UTC().Format(time.RFC3339)+"</updated>")
+
+	rec = get("/feed.json")
+	var jsonFeed struct {
+		FeedURL string `json:"feed_url"`
+		Items   []struct {
+			URL         string `json:"url"`
+			ContentHTML string `json:"content_html"`
+		} `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jsonFeed))
+	assert.Equal(t, "https://blog.example.com/feed.json", jsonFeed.FeedURL)
+	assert.Len(t, jsonFeed.Items, 1)
+	assert.Contains(t, jsonFeed.Items[0].ContentHTML, "<strong>body</strong>")
+
+	rec = get("/users/" + _user.GetStringID() + "/feed.xml")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Feed title")
+	assert.Equal(t, http.StatusNotFound, get("/users/99/feed.xml").Code)
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"goblog/pkg/logger"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLoggerWritesLeveledJSONWithContext(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "goblog.log")
+	logger.InitLogger(logger.Options{
+		Level:    "info",
+		Format:   "json",
+		Channel:  "file",
+		Filename: file,
+		MaxSize:  1,
+	})
+	defer logger.InitLogger(logger.Options{Level: "debug"})
+
+	ctx := logger.NewContext(context.Background(), zap.String("request_id", "abc123"))
+	logger.WithContext(ctx).Info("hello")
+	logger.Debug("debug 级别低于 info，不会输出")
+
+	// LogError 只记录日志，不会退出程序
+	logger.LogError(errors.New("something went wrong"))
+
+	content, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"request_id":"abc123"`)
+	assert.Contains(t, string(content), `"level":"ERROR"`)
+	assert.NotContains(t, string(content), "debug 级别")
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/pkg/markdown"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownRender(t *testing.T) {
+	html := string(markdown.Render("## 数据库 设计\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n```go\nfmt.Println()\n```\n\n正文[^1]\n\n[^1]: 脚注"))
+
+	assert.Contains(t, html, `<h2 id="数据库-设计">`)
+	assert.Contains(t, html, "<table>")
+	assert.Contains(t, html, `<code class="language-go">`)
+	assert.Contains(t, html, `id="fn:1"`)
+}
+
+func TestMarkdownSanitize(t *testing.T) {
+	html := string(markdown.Render("<script>alert(1)</script>\n\n[x](javascript:alert(1)) <img src=x onerror=alert(1)>"))
+
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "javascript:")
+	assert.NotContains(t, html, "onerror")
+}
+
+func TestArticleHTMLCachedPerRevision(t *testing.T) {
+	_article := article.Article{Body: "# v1"}
+	_article.ID = 42
+	_article.UpdatedAt = time.Now()
+	assert.Contains(t, string(_article.HTML()), "v1")
+
+	// 同一版本读取缓存
+	_article.Body = "# v2"
+	assert.Contains(t, string(_article.HTML()), "v1")
+
+	// 更新时间变化即新版本，重新渲染
+	_article.UpdatedAt = _article.UpdatedAt.Add(time.Second)
+	assert.Contains(t, string(_article.HTML()), "v2")
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/bootstrap"
+	"goblog/pkg/model"
+	"goblog/pkg/pagination"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination(t *testing.T) {
+	setupSQLite(t)
+	bootstrap.SetupRoute()
+
+	for i := 0; i < 25; i++ {
+		_article := article.Article{Title: "title", Body: "body", UserID: 1}
+		assert.NoError(t, _article.Create())
+	}
+
+	r := httptest.NewRequest("GET", "/?page=2&q=go", nil)
+	_pager := pagination.New(r, model.DB.Model(article.Article{}).Order("id"), 10, "home")
+	data := _pager.Paging()
+
+	assert.Equal(t, 2, data.CurrentPage)
+	assert.Equal(t, 3, data.TotalPage)
+	assert.Equal(t, int64(25), data.TotalCount)
+	assert.True(t, data.HasPages)
+
+	// 其他 URL 参数保留，第一页不带页码参数
+	assert.Equal(t, "/?q=go", data.PrevPageURL)
+	assert.Equal(t, "/?page=3&q=go", data.NextPageURL)
+	assert.Len(t, data.Pages, 3)
+	assert.True(t, data.Pages[1].Active)
+
+	var articles []article.Article
+	assert.NoError(t, _pager.Results(&articles))
+	assert.Len(t, articles, 10)
+	assert.Equal(t, uint64(11), articles[0].ID)
+
+	// 超出范围或非法的页码
+	r = httptest.NewRequest("GET", "/?page=abc", nil)
+	assert.Equal(t, 1, pagination.New(r, model.DB.Model(article.Article{}), 10, "home").Page)
+
+	r = httptest.NewRequest("GET", "/?page=9", nil)
+	data = pagination.New(r, model.DB.Model(article.Article{}), 10, "home").Paging()
+	assert.Empty(t, data.NextPageURL)
+	assert.Equal(t, "/?page=3", data.PrevPageURL)
+
+	// 已知总数时不再查询数据库
+	r = httptest.NewRequest("GET", "/?page=5", nil)
+	data = pagination.New(r, nil, 2, "home").WithTotal(100).Paging()
+	assert.Equal(t, 50, data.TotalPage)
+	assert.Len(t, data.Pages, 9) // 1 … 3 4 5 6 7 … 50
+
+	// 统计失败时不把数据库错误当作“没有数据”
+	_pager = pagination.New(r, model.DB.Table("missing_table"), 10, "home")
+	assert.Zero(t, _pager.Paging().TotalCount)
+	assert.Error(t, _pager.Results(&articles))
+}
+
+func TestArticleGetAllPaginated(t *testing.T) {
+	setupSQLite(t)
+	bootstrap.SetupRoute()
+
+	for i := 0; i < 3; i++ {
+		_article := article.Article{Title: "title", Body: "body", UserID: 1, TagNames: "Go"}
+		assert.NoError(t, _article.Create())
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	articles, data, err := article.GetAll(r, 2)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.Equal(t, 2, data.TotalPage)
+	assert.Equal(t, "/?page=2", data.NextPageURL)
+
+	// 按发布时间倒序，关联数据一并读取
+	assert.Equal(t, uint64(3), articles[0].ID)
+	assert.Equal(t, "Go", articles[0].TagList())
+}
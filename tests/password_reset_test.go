@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"errors"
+	"goblog/app/models/passwordreset"
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"goblog/pkg/mail"
+	"goblog/pkg/throttle"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordReset(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	c.Viper.Set("app.url", "https://blog.example.com")
+	mailer := mail.NewMemoryMailer()
+	mail.SetMailer(mailer)
+	router := bootstrap.SetupRoute()
+
+	_user := user.User{Name: "summer", Email: "summer@example.com", Password: "secret"}
+	assert.NoError(t, _user.Create())
+
+	client := &apiClient{router: router}
+	client.login("nobody@example.com") // 仅获取 CSRF 令牌
+
+	// 未注册的邮箱同样提示已发送，但不发送邮件
+	rec := client.do("POST", "/auth/password/email", url.Values{"email": {"nobody@example.com"}}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Empty(t, mailer.Messages())
+
+	rec = client.do("POST", "/auth/password/email", url.Values{"email": {"summer@example.com"}}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+	msg, ok := mailer.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "summer@example.com", msg.To[0].Address)
+	link := regexp.MustCompile(`https://blog\.example\.com/auth/password/reset/([0-9a-f]+)`).FindStringSubmatch(msg.Text)
+	if !assert.NotNil(t, link) {
+		return
+	}
+	plainText := link[1]
+
+	// 只保存摘要
+	_, err := passwordreset.FindValid(plainText)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, client.do("GET", "/auth/password/reset/"+plainText, "").Code)
+	assert.Equal(t, http.StatusFound, client.do("GET", "/auth/password/reset/invalid", "").Code)
+
+	// 两次密码不一致
+	rec = client.do("POST", "/auth/password/reset", url.Values{"token": {plainText}, "password": {"new-secret"}, "password_confirm": {"other"}}.Encode())
+	assert.Contains(t, rec.Body.String(), "两次输入密码不匹配")
+
+	before, _ := user.Get(_user.GetStringID())
+	rec = client.do("POST", "/auth/password/reset", url.Values{"token": {plainText}, "password": {"new-secret"}, "password_confirm": {"new-secret"}}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/auth/login", rec.Header().Get("Location"))
+
+	// 新密码经 BeforeSave 加密保存，令牌只能使用一次
+	updated, _ := user.Get(_user.GetStringID())
+	assert.NotEqual(t, "new-secret", updated.Password)
+	assert.True(t, updated.ComparePassword("new-secret"))
+	assert.NotEqual(t, before.RememberToken, updated.RememberToken, "修改密码后“记住我”失效")
+	_, err = passwordreset.FindValid(plainText)
+	assert.Error(t, err)
+
+	// 过期的令牌无效
+	c.Viper.Set("auth.password_reset_expire", -1)
+	defer c.Viper.Set("auth.password_reset_expire", 60)
+	expired, err := passwordreset.Create(_user.ID)
+	assert.NoError(t, err)
+	_, err = passwordreset.FindValid(expired)
+	assert.Error(t, err)
+}
+
+// failingMailer 发送总是失败的邮件驱动
+type failingMailer struct{}
+
+func (failingMailer) Send(msg mail.Message) error {
+	return errors.New("smtp: connection refused")
+}
+
+func TestPasswordResetLinkHidesFailuresAndIsThrottled(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	throttle.SetStore(throttle.NewMemoryStore())
+	defer throttle.SetStore(throttle.NewMemoryStore())
+	router := bootstrap.SetupRoute()
+	createVerifiedUser(t, "summer", "summer@example.com")
+
+	client := &apiClient{router: router}
+	client.login("nobody@example.com") // 仅获取 CSRF 令牌
+	send := func(email string) string {
+		client.do("POST", "/auth/password/email", url.Values{"email": {email}}.Encode())
+		return client.do("GET", "/auth/password/forgot", "").Body.String()
+	}
+
+	// 发送失败时的提示与未注册时相同，不泄露邮箱是否注册
+	mail.SetMailer(failingMailer{})
+	assert.Contains(t, send("summer@example.com"), "如果该邮箱已注册")
+	assert.Contains(t, send("nobody@example.com"), "如果该邮箱已注册")
+
+	// 同一邮箱超过次数上限后不再发送
+	mailer := mail.NewMemoryMailer()
+	mail.SetMailer(mailer)
+	assert.Contains(t, send("summer@example.com"), "如果该邮箱已注册")
+	assert.Contains(t, send("summer@example.com"), "如果该邮箱已注册")
+	assert.Contains(t, send("summer@example.com"), "操作过于频繁")
+	assert.Len(t, mailer.Messages(), 2)
+}
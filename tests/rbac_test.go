@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/role"
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	"goblog/pkg/auth"
+	"goblog/policies"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleBasedAccessControl(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	router := bootstrap.SetupRoute()
+
+	admin := createVerifiedUser(t, "admin", "admin@example.com")
+	editor := createVerifiedUser(t, "editor", "editor@example.com")
+	author := createVerifiedUser(t, "author", "author@example.com")
+	reader := createVerifiedUser(t, "reader", "reader@example.com")
+	assert.NoError(t, role.Sync(admin.ID, role.Admin))
+	assert.NoError(t, role.Sync(editor.ID, role.Editor))
+	assert.NoError(t, role.Sync(reader.ID, role.Reader))
+	assert.Error(t, role.Sync(reader.ID, "nobody"), "不存在的角色应报错")
+
+	_article := article.Article{Title: "Author title", Body: "Author body long enough", UserID: author.ID}
+	assert.NoError(t, _article.Create())
+
+	// 授权规则：作者可以修改自己的文章，编辑和管理员可以修改所有文章，游客不具备任何能力
+	assert.True(t, policies.Can(author, role.PermissionUpdateArticles, _article))
+	assert.False(t, policies.Can(author, role.PermissionUpdateArticles, nil))
+	assert.True(t, policies.Can(editor, role.PermissionDeleteArticles, _article))
+	assert.False(t, policies.Can(editor, role.PermissionManageUsers, nil))
+	assert.True(t, policies.Can(admin, role.PermissionManageUsers, nil))
+	assert.False(t, policies.Can(reader, role.PermissionCreateArticles, nil))
+	assert.False(t, policies.Can(user.User{}, role.PermissionCreateArticles, nil))
+
+	// 读者不能发布文章
+	readerClient := &apiClient{router: router}
+	readerClient.login("reader@example.com")
+	assert.Equal(t, http.StatusForbidden, readerClient.do("GET", "/articles/create", "").Code)
+	assert.Equal(t, http.StatusForbidden, readerClient.do("POST", "/api/v1/articles", `{"title":"Reader title","body":"A body long enough"}`).Code)
+	assert.NotContains(t, readerClient.do("GET", "/", "").Body.String(), "开始写作")
+
+	// 编辑可以修改和删除他人的文章
+	id := _article.GetStringID()
+	editorClient := &apiClient{router: router}
+	editorClient.login("editor@example.com")
+	assert.Equal(t, http.StatusOK, editorClient.do("PATCH", "/api/v1/articles/"+id, `{"title":"Edited title"}`).Code)
+	edited, _ := article.Get(id)
+	assert.Equal(t, "Edited title", edited.Title)
+	assert.Equal(t, author.ID, edited.UserID, "编辑修改后作者不变")
+	assert.Equal(t, http.StatusForbidden, editorClient.do("GET", "/admin/users", "").Code)
+	assert.Equal(t, http.StatusNoContent, editorClient.do("DELETE", "/api/v1/articles/"+id, "").Code)
+
+	// 只有管理员可以管理用户角色
+	adminClient := &apiClient{router: router}
+	adminClient.login("admin@example.com")
+	rec := adminClient.do("GET", "/admin/users", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reader@example.com")
+
+	rec = adminClient.do("POST", "/admin/users/"+reader.GetStringID()+"/roles", url.Values{"roles": {role.Author}}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+	names, err := role.NamesOf(reader.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{role.Author}, names)
+	assert.Equal(t, http.StatusOK, readerClient.do("GET", "/articles/create", "").Code)
+
+	// 管理员不能移除自己的管理员角色
+	adminClient.do("POST", "/admin/users/"+admin.GetStringID()+"/roles", url.Values{"roles": {role.Editor}}.Encode())
+	names, _ = role.NamesOf(admin.ID)
+	assert.Equal(t, []string{role.Admin}, names)
+}
+
+func TestGrantsAreCachedPerRequest(t *testing.T) {
+	setupSQLite(t)
+	reader := createVerifiedUser(t, "reader", "reader@example.com")
+	assert.NoError(t, role.Sync(reader.ID, role.Reader, role.Reader), "重复的角色名应去重")
+
+	r := policies.WithCache(auth.WithUser(httptest.NewRequest("GET", "/", nil), reader))
+	assert.False(t, policies.Allows(r, role.PermissionCreateArticles, nil))
+
+	// 同一请求内沿用已读取的角色，新请求读取到变更后的角色
+	assert.NoError(t, role.Sync(reader.ID, role.Author))
+	assert.False(t, policies.Allows(r, role.PermissionCreateArticles, nil))
+	r = policies.WithCache(auth.WithUser(httptest.NewRequest("GET", "/", nil), reader))
+	assert.True(t, policies.Allows(r, role.PermissionCreateArticles, nil))
+}
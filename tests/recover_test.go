@@ -0,0 +1,25 @@
+package tests
+
+import (
+	middwares "goblog/app/http/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverRespondsWithJSONError(t *testing.T) {
+	handler := middwares.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	r := httptest.NewRequest("GET", "/articles/1", nil)
+	r.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.JSONEq(t, `{"code":500,"message":"服务器内部错误，请稍后再试"}`, rec.Body.String())
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRememberMe(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	router := bootstrap.SetupRoute()
+	_user := createVerifiedUser(t, "summer", "summer@example.com")
+
+	// rememberedClient 登录并勾选“记住我”，返回只携带“记住我” Cookie 的新客户端
+	rememberedClient := func() *apiClient {
+		client := &apiClient{router: router}
+		m := csrfMeta.FindStringSubmatch(client.do("GET", "/auth/login", "").Body.String())
+		client.csrfToken = m[1]
+		rec := client.do("POST", "/auth/dologin", url.Values{"email": {"summer@example.com"}, "password": {"secret"}, "remember": {"on"}}.Encode())
+		assert.Equal(t, http.StatusFound, rec.Code)
+
+		fresh := &apiClient{router: router}
+		for _, cookie := range client.cookies {
+			if cookie.Name == "goblog-remember" {
+				fresh.cookies = append(fresh.cookies, cookie)
+			}
+		}
+		assert.Len(t, fresh.cookies, 1)
+		return fresh
+	}
+
+	// 会话丢失后通过“记住我”恢复登录
+	device1 := rememberedClient()
+	firstRemembered := device1.cookies[0]
+	assert.Equal(t, http.StatusOK, device1.do("GET", "/articles/create", "").Code)
+
+	// 伪造的 Cookie 无效
+	forged := &apiClient{router: router}
+	forged.cookies = append(forged.cookies, &http.Cookie{Name: "goblog-remember", Value: _user.GetStringID() + "|forged"})
+	assert.Equal(t, http.StatusFound, forged.do("GET", "/articles/create", "").Code)
+
+	// 退出登录后所有设备的“记住我”失效
+	device2 := rememberedClient()
+	remembered := device2.cookies[0]
+	m := csrfMeta.FindStringSubmatch(device2.do("GET", "/", "").Body.String())
+	device2.csrfToken = m[1]
+	assert.Equal(t, http.StatusFound, device2.do("POST", "/auth/logout", "").Code)
+	for _, cookie := range []*http.Cookie{firstRemembered, remembered} {
+		stale := &apiClient{router: router, cookies: []*http.Cookie{cookie}}
+		assert.Equal(t, http.StatusFound, stale.do("GET", "/articles/create", "").Code)
+	}
+
+	// 修改密码后“记住我”失效，与修改密码的入口无关
+	device3 := rememberedClient()
+	_user, _ = user.Get(_user.GetStringID())
+	_user.Password = "new-secret"
+	_, err := _user.Update()
+	assert.NoError(t, err)
+	stale := &apiClient{router: router, cookies: []*http.Cookie{device3.cookies[0]}}
+	assert.Equal(t, http.StatusFound, stale.do("GET", "/articles/create", "").Code)
+}
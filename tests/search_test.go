@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/bootstrap"
+	"goblog/pkg/search"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"gorm", "v2", "中间", "间件", "设计"}, search.Tokenize("GORM v2，中间件 设计"))
+	assert.Equal(t, []string{"go", "语"}, search.Tokenize("Go语"))
+	assert.Equal(t, []string{"博客", "博", "客"}, search.IndexTokens("博客"))
+}
+
+func TestMemorySearchEngine(t *testing.T) {
+	engine := search.NewMemoryEngine()
+	now := time.Now()
+	assert.NoError(t, engine.Rebuild([]search.Document{
+		{ID: 1, UserID: 1, Title: "Go 中间件设计", Body: "介绍中间件", CreatedAt: now.AddDate(0, -2, 0)},
+		{ID: 2, UserID: 2, Title: "路由", Body: "路由和中间件的关系", CreatedAt: now.AddDate(0, -1, 0)},
+		{ID: 3, UserID: 2, Title: "GORM", Body: "database access in Go", CreatedAt: now},
+	}))
+
+	// 标题命中的相关度更高
+	result, err := engine.Search(search.Query{Keywords: "中间件"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, result.IDs)
+
+	// 单个字也能匹配词语中的该字
+	result, _ = engine.Search(search.Query{Keywords: "件"})
+	assert.Equal(t, []uint64{1, 2}, result.IDs)
+	result, _ = engine.Search(search.Query{Keywords: "由"})
+	assert.Equal(t, []uint64{2}, result.IDs)
+
+	// 需包含全部关键词
+	result, _ = engine.Search(search.Query{Keywords: "go database"})
+	assert.Equal(t, []uint64{3}, result.IDs)
+
+	// 作者和日期过滤
+	result, _ = engine.Search(search.Query{Keywords: "中间件", UserID: 2})
+	assert.Equal(t, []uint64{2}, result.IDs)
+	result, _ = engine.Search(search.Query{Keywords: "go", From: now.AddDate(0, 0, -7)})
+	assert.Equal(t, []uint64{3}, result.IDs)
+
+	// 分页
+	result, _ = engine.Search(search.Query{Keywords: "go", Offset: 1, Limit: 1})
+	assert.Equal(t, int64(2), result.Total)
+	assert.Len(t, result.IDs, 1)
+
+	assert.NoError(t, engine.Remove(1))
+	result, _ = engine.Search(search.Query{Keywords: "中间件"})
+	assert.Equal(t, []uint64{2}, result.IDs)
+}
+
+func TestArticleHooksUpdateSearchIndex(t *testing.T) {
+	setupSQLite(t)
+	search.SetEngine(search.NewMemoryEngine())
+
+	_article := article.Article{Title: "Hello search", Body: "**Markdown** body", UserID: 1}
+	assert.NoError(t, _article.Create())
+	result, _ := search.Search(search.Query{Keywords: "markdown"})
+	assert.Equal(t, []uint64{_article.ID}, result.IDs)
+
+	_article.Body = "changed body"
+	_, err := _article.Update()
+	assert.NoError(t, err)
+	result, _ = search.Search(search.Query{Keywords: "markdown"})
+	assert.Empty(t, result.IDs)
+	result, _ = search.Search(search.Query{Keywords: "changed"})
+	assert.Equal(t, []uint64{_article.ID}, result.IDs)
+
+	_, err = _article.Delete()
+	assert.NoError(t, err)
+	result, _ = search.Search(search.Query{Keywords: "changed"})
+	assert.Empty(t, result.IDs)
+
+	// 启动时从数据库重建索引
+	other := article.Article{Title: "Rebuild", Body: "body", UserID: 1}
+	assert.NoError(t, other.Create())
+	search.SetEngine(search.NewMemoryEngine())
+	assert.NoError(t, bootstrap.SetupSearch())
+	result, _ = search.Search(search.Query{Keywords: "rebuild"})
+	assert.Equal(t, []uint64{other.ID}, result.IDs)
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t, `<mark>Go</mark> &lt;b&gt; <mark>go</mark>`, string(search.Highlight("Go <b> go", "go", 0)))
+	assert.Equal(t, `学习<mark>中间件</mark>`, string(search.Highlight("学习中间件", "中间件", 0)))
+
+	text := "aaaa bbbb cccc dddd eeee keyword ffff gggg hhhh"
+	assert.Equal(t, "…eeee <mark>keyword</mark> ffff gggg h…", string(search.Highlight(text, "keyword", 24)))
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/user"
+	"goblog/database/factories"
+	"goblog/database/seeders"
+	"goblog/pkg/model"
+	"goblog/pkg/seed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedAll(t *testing.T) {
+	setupSQLite(t)
+	seeders.Initialize()
+
+	assert.NoError(t, seed.RunAll(model.DB))
+
+	var users, articles int64
+	model.DB.Model(&user.User{}).Count(&users)
+	model.DB.Model(&article.Article{}).Count(&articles)
+	assert.Equal(t, int64(10), users)
+	assert.Equal(t, int64(50), articles)
+
+	// 密码经 BeforeSave 钩子加密，可以使用默认密码登录
+	demo, err := user.GetByEmail("summer@example.com")
+	assert.NoError(t, err)
+	assert.True(t, demo.ComparePassword(factories.DefaultPassword))
+
+	// 可以重复执行，沿用已有的演示账号、分类和标签
+	assert.NoError(t, seed.RunAll(model.DB))
+	model.DB.Model(&user.User{}).Where("email = ?", "summer@example.com").Count(&users)
+	assert.Equal(t, int64(1), users)
+}
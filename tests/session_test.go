@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"fmt"
+	"goblog/config"
+	c "goblog/pkg/config"
+	"goblog/pkg/realip"
+	"goblog/pkg/session"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	config.Initialize()
+}
+
+func TestSessionIsRequestScoped(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = session.StartSession(w, r)
+		uid := r.URL.Query().Get("uid")
+		session.Put(r, "uid", uid)
+		fmt.Fprint(w, session.Get(r, "uid"))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uid := fmt.Sprint(i)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("GET", "/?uid="+uid, nil))
+			assert.Equal(t, uid, rec.Body.String(), "并发请求之间的会话数据不应互相覆盖")
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSessionWithoutStartIsNoop(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	session.Put(r, "uid", "1")
+	assert.Nil(t, session.Get(r, "uid"))
+}
+
+func TestFileSessionDriverRevokeUser(t *testing.T) {
+	handler, err := session.NewFileHandler(t.TempDir())
+	assert.NoError(t, err)
+	store := session.NewServerStore(handler, &sessions.Options{Path: "/", MaxAge: 60}, []byte("test-key"))
+
+	// 1. 登录并保存会话
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	s, err := store.Get(r, "goblog-session")
+	assert.NoError(t, err)
+	s.Values["uid"] = "7"
+	assert.NoError(t, store.Save(r, rec, s))
+
+	// 2. 携带 Cookie 再次请求能读取到会话
+	r = httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range rec.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+	s, err = store.New(r, "goblog-session")
+	assert.NoError(t, err)
+	assert.False(t, s.IsNew)
+	assert.Equal(t, "7", s.Values["uid"])
+
+	records, err := handler.UserRecords("7")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	// 3. 强制注销后会话失效
+	assert.NoError(t, handler.DestroyUser("7"))
+	s, err = store.New(r, "goblog-session")
+	assert.NoError(t, err)
+	assert.True(t, s.IsNew)
+	assert.Nil(t, s.Values["uid"])
+}
+
+func TestServerSessionRegenerate(t *testing.T) {
+	handler, err := session.NewFileHandler(t.TempDir())
+	assert.NoError(t, err)
+	store := session.NewServerStore(handler, &sessions.Options{Path: "/", MaxAge: 60}, []byte("test-key"))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	s, err := store.Get(r, "goblog-session")
+	assert.NoError(t, err)
+	s.Values["_csrf_token"] = "planted"
+	assert.NoError(t, store.Save(r, httptest.NewRecorder(), s))
+	oldID := s.ID
+
+	// 登录时更换会话 ID，旧会话被删除，会话数据保留
+	assert.NoError(t, store.Regenerate(s))
+	s.Values["uid"] = "7"
+	assert.NoError(t, store.Save(r, httptest.NewRecorder(), s))
+	assert.NotEqual(t, oldID, s.ID)
+
+	_, err = handler.Read(oldID)
+	assert.Equal(t, session.ErrSessionNotFound, err)
+	rec, err := handler.Read(s.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", rec.UserID)
+}
+
+func TestRealIPIgnoresUntrustedForwardedFor(t *testing.T) {
+	defer c.Viper.Set("app.trusted_proxies", "")
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.5:1234"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4, 203.0.113.9")
+	assert.Equal(t, "10.0.0.5", realip.FromRequest(r), "未配置受信任代理时不读取 X-Forwarded-For")
+
+	// 受信任代理转发的请求，取最右侧的非代理地址，客户端自行添加的地址被忽略
+	c.Viper.Set("app.trusted_proxies", "10.0.0.0/8")
+	assert.Equal(t, "203.0.113.9", realip.FromRequest(r))
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSitemap(t *testing.T) {
+	setupSQLite(t)
+	c.Viper.Set("app.url", "https://blog.example.com")
+	router := bootstrap.SetupRoute()
+
+	_user := user.User{Name: "summer", Email: "summer@example.com", Password: "$2a$14$" + strings.Repeat("a", 53)}
+	assert.NoError(t, _user.Create())
+	for i := 0; i < 3; i++ {
+		_article := article.Article{Title: "title", Body: "body", UserID: _user.ID}
+		assert.NoError(t, _article.Create())
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	// 静态页面 2 个、用户 1 个、文章 3 篇
+	rec := get("/sitemap.xml")
+	body := rec.Body.String()
+	assert.Equal(t, 6, strings.Count(body, "<url>"))
+	assert.Contains(t, body, "<loc>https://blog.example.com/about</loc>")
+	assert.Contains(t, body, "<loc>https://blog.example.com/articles/3</loc>")
+	assert.Contains(t, body, "<lastmod>"+time.Now().UTC().Format("2006-01-02"))
+
+	// 超过上限时输出索引，分段跨越不同类型的链接
+	c.Viper.Set("sitemap.max_urls", 4)
+	defer c.Viper.Set("sitemap.max_urls", 50000)
+
+	body = get("/sitemap.xml").Body.String()
+	assert.Contains(t, body, "<sitemapindex")
+	assert.Contains(t, body, "<loc>https://blog.example.com/sitemap-2.xml</loc>")
+	assert.NotContains(t, body, "sitemap-3.xml")
+
+	body = get("/sitemap-2.xml").Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<url>"))
+	assert.Contains(t, body, "/articles/2</loc>")
+	assert.Equal(t, http.StatusNotFound, get("/sitemap-3.xml").Code)
+}
+
+func TestRobots(t *testing.T) {
+	router := bootstrap.SetupRoute()
+	get := func() string {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", "/robots.txt", nil))
+		return rec.Body.String()
+	}
+
+	env := c.GetString("app.env")
+	defer c.Viper.Set("app.env", env)
+
+	c.Viper.Set("app.env", "production")
+	body := get()
+	assert.Contains(t, body, "Disallow: /auth/*\n")
+	assert.NotContains(t, body, "Disallow: /\n")
+	assert.Contains(t, body, "Sitemap: ")
+
+	c.Viper.Set("app.env", "local")
+	assert.Contains(t, get(), "Disallow: /\n")
+
+	// 未配置的环境使用 default，默认禁止抓取整个站点
+	c.Viper.Set("app.env", "staging")
+	assert.Contains(t, get(), "Disallow: /\n")
+
+	fallback := c.GetString("robots.disallow.default")
+	defer c.Viper.Set("robots.disallow.default", fallback)
+	c.Viper.Set("robots.disallow.default", "/admin/*")
+	body = get()
+	assert.Contains(t, body, "Disallow: /admin/*\n")
+	assert.NotContains(t, body, "Disallow: /\n")
+}
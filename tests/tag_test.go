@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"goblog/app/models/article"
+	"goblog/app/models/tag"
+	"goblog/app/requests"
+	"goblog/bootstrap"
+	"goblog/pkg/model"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagNames(t *testing.T) {
+	assert.Equal(t, []string{"Go", "GORM", "中间件 设计"}, tag.ParseNames(" Go, GORM，go,, 中间件  设计 "))
+	assert.Empty(t, tag.ParseNames(" , ，"))
+
+	assert.Equal(t, "new-tag", tag.MakeSlug("New  Tag"))
+	assert.Equal(t, "中间件", tag.MakeSlug("中间件"))
+	assert.Equal(t, "c", tag.MakeSlug("C++"))
+}
+
+func TestArticleFormValidatesTags(t *testing.T) {
+	setupSQLite(t)
+
+	_article := article.Article{Title: "hello", Body: "some long body", TagNames: "a, b, c, d, e"}
+	assert.NotContains(t, requests.ValidateArticleForm(_article), "tags")
+
+	_article.TagNames = "a, b, c, d, e, f"
+	assert.Len(t, requests.ValidateArticleForm(_article)["tags"], 1)
+
+	_article.TagNames = "一二三四五六七八九十一二三四五六七八九十一"
+	assert.Len(t, requests.ValidateArticleForm(_article)["tags"], 1)
+}
+
+func TestArticleTagsSync(t *testing.T) {
+	setupSQLite(t)
+
+	first := article.Article{Title: "first", Body: "body", UserID: 1, TagNames: "Go, C++"}
+	assert.NoError(t, first.Create())
+	second := article.Article{Title: "second", Body: "body", UserID: 1, TagNames: "go, C"}
+	assert.NoError(t, second.Create())
+
+	// 名称不区分大小写，别名冲突时自动追加校验值
+	goTag, err := tag.GetBySlug("go")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", goTag.Name)
+	cTag, err := tag.GetBySlug("c")
+	assert.NoError(t, err)
+	assert.Equal(t, "C++", cTag.Name)
+
+	bootstrap.SetupRoute()
+	r := httptest.NewRequest("GET", "/tags/go", nil)
+	articles, _, err := article.GetByTagID(r, goTag.GetStringID(), goTag.Slug, 10)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.Len(t, articles[0].Tags, 2)
+
+	cloud, err := tag.Cloud(10)
+	assert.NoError(t, err)
+	assert.Len(t, cloud, 3)
+	for _, item := range cloud {
+		if item.ID == goTag.ID {
+			assert.Equal(t, 5, item.Weight)
+		} else {
+			assert.Equal(t, 1, item.Weight)
+		}
+	}
+
+	// 更新时按表单内容替换标签
+	first.TagNames = "Markdown"
+	_, err = first.Update()
+	assert.NoError(t, err)
+	_article, err := article.Get(first.GetStringID())
+	assert.NoError(t, err)
+	assert.Equal(t, "Markdown", _article.TagList())
+
+	// 删除文章时清理关联记录
+	_, err = second.Delete()
+	assert.NoError(t, err)
+	var count int64
+	model.DB.Table("article_tags").Where("article_id = ?", second.ID).Count(&count)
+	assert.Zero(t, count)
+}
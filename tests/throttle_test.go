@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"goblog/pkg/throttle"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterBackoffAndLockout(t *testing.T) {
+	setupSQLite(t)
+
+	for name, store := range map[string]throttle.Store{
+		"memory":   throttle.NewMemoryStore(),
+		"database": throttle.NewDatabaseStore(),
+	} {
+		limiter := &throttle.Limiter{Store: store, MaxAttempts: 4, Backoff: time.Second, Lockout: time.Minute}
+
+		// 退避间隔逐次翻倍，达到上限后锁定
+		for i, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Minute} {
+			attempts, wait, err := limiter.Hit("login:email:summer@example.com")
+			assert.NoError(t, err, name)
+			assert.Equal(t, i+1, attempts, name)
+			assert.Equal(t, expected, wait, name)
+		}
+		attempts, _, _ := limiter.Hit("login:email:summer@example.com")
+		assert.True(t, limiter.TooMany(attempts), name)
+		wait, err := limiter.AvailableIn("login:email:summer@example.com")
+		assert.NoError(t, err, name)
+		assert.True(t, wait > 59*time.Second, name)
+
+		// 其他键不受影响，清除后可立即重试
+		wait, _ = limiter.AvailableIn("login:email:winter@example.com")
+		assert.Zero(t, wait, name)
+		assert.NoError(t, limiter.Clear("login:email:summer@example.com"), name)
+		wait, _ = limiter.AvailableIn("login:email:summer@example.com")
+		assert.Zero(t, wait, name)
+	}
+}
+
+func TestLimiterCountsConcurrentHits(t *testing.T) {
+	setupSQLite(t)
+
+	for name, store := range map[string]throttle.Store{
+		"memory":   throttle.NewMemoryStore(),
+		"database": throttle.NewDatabaseStore(),
+	} {
+		limiter := &throttle.Limiter{Store: store, MaxAttempts: 5, Lockout: time.Minute}
+
+		// 并发的尝试逐一计数，只有 MaxAttempts 次能通过
+		var wg sync.WaitGroup
+		var allowed int32
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				attempts, _, err := limiter.Hit("login:ip:192.0.2.1")
+				if assert.NoError(t, err, name) && !limiter.TooMany(attempts) {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, 5, allowed, name)
+	}
+}
+
+func TestLoginThrottling(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	throttle.SetStore(throttle.NewMemoryStore())
+	c.Viper.Set("throttle.login.max_attempts", 3)
+	c.Viper.Set("throttle.login.backoff", 0)
+	defer func() {
+		throttle.SetStore(throttle.NewMemoryStore())
+		c.Viper.Set("throttle.login.max_attempts", 5)
+		c.Viper.Set("throttle.login.backoff", 1)
+	}()
+	router := bootstrap.SetupRoute()
+	createVerifiedUser(t, "summer", "summer@example.com")
+
+	client := &apiClient{router: router}
+	client.csrfToken = csrfMeta.FindStringSubmatch(client.do("GET", "/auth/login", "").Body.String())[1]
+	login := func(password string) string {
+		form := url.Values{"email": {"summer@example.com"}, "password": {password}}.Encode()
+		return client.do("POST", "/auth/dologin", form).Body.String()
+	}
+
+	assert.Contains(t, login("wrong"), "账号不存在或密码错误")
+	assert.Contains(t, login("wrong"), "账号不存在或密码错误")
+	assert.Contains(t, login("wrong"), "请在 900 秒后重试")
+
+	// 锁定期间正确的密码同样被拒绝，锁定从校验密码之前开始计时，剩余时间可能已不足 900 秒
+	assert.Regexp(t, `请在 (899|900) 秒后重试`, login("secret"))
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"goblog/app/models/token"
+	"goblog/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessTokens(t *testing.T) {
+	setupSQLite(t)
+	router := bootstrap.SetupRoute()
+
+	_user := createVerifiedUser(t, "summer", "summer@example.com")
+
+	readOnly := token.PersonalAccessToken{UserID: _user.ID, Name: "reader", Scopes: token.ScopeRead}
+	readPlain, err := readOnly.Create()
+	assert.NoError(t, err)
+	writer := token.PersonalAccessToken{UserID: _user.ID, Name: "writer", Scopes: "read,write"}
+	writePlain, err := writer.Create()
+	assert.NoError(t, err)
+
+	// 只保存摘要
+	assert.True(t, strings.HasPrefix(writePlain, "gb_"))
+	assert.Equal(t, token.Hash(writePlain), writer.TokenHash)
+	assert.NotContains(t, writer.TokenHash, writePlain)
+
+	post := func(bearer string) int {
+		req := httptest.NewRequest("POST", "/api/v1/articles", strings.NewReader(`{"title":"Token title","body":"Published with a token"}`))
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post("gb_invalid"))
+	assert.Equal(t, http.StatusForbidden, post(readPlain), "只读令牌不能创建文章")
+	assert.Equal(t, http.StatusCreated, post(writePlain))
+
+	used, err := token.FindByPlainText(writePlain)
+	assert.NoError(t, err)
+	assert.NotNil(t, used.LastUsedAt)
+	assert.Equal(t, _user.ID, used.User.ID)
+
+	// 撤销后立即失效
+	_, err = writer.Delete()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, post(writePlain))
+
+	chdirToRoot(t)
+	// 设置页面创建令牌，明文只在创建后的页面中出现
+	client := &apiClient{router: router}
+	client.login("summer@example.com")
+	rec := client.do("POST", "/settings/tokens", url.Values{"name": {"editor"}, "scopes": {"read", "write"}}.Encode())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `value="gb_`)
+	tokens, _ := token.GetByUserID(_user.ID)
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, "read,write", tokens[0].Scopes)
+	assert.NotContains(t, client.do("GET", "/settings/tokens", "").Body.String(), `value="gb_`)
+
+	rec = client.do("POST", "/settings/tokens", url.Values{"name": {""}}.Encode())
+	assert.Contains(t, rec.Body.String(), "请至少选择一项权限")
+}
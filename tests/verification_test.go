@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"goblog/app/models/user"
+	"goblog/bootstrap"
+	c "goblog/pkg/config"
+	"goblog/pkg/mail"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerification(t *testing.T) {
+	setupSQLite(t)
+	chdirToRoot(t)
+	c.Viper.Set("app.url", "https://blog.example.com")
+	mailer := mail.NewMemoryMailer()
+	mail.SetMailer(mailer)
+	router := bootstrap.SetupRoute()
+
+	// 注册后登录并发送验证邮件
+	client := &apiClient{router: router}
+	client.login("nobody@example.com") // 仅获取 CSRF 令牌
+	rec := client.do("POST", "/auth/do-register", url.Values{
+		"name": {"summer"}, "email": {"summer@example.com"}, "password": {"secret"}, "password_confirm": {"secret"},
+	}.Encode())
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/auth/verify-email", rec.Header().Get("Location"))
+
+	msg, ok := mailer.Last()
+	if !assert.True(t, ok) {
+		return
+	}
+	link := regexp.MustCompile(`https://blog\.example\.com(/auth/verify-email/\S+)`).FindStringSubmatch(msg.Text)
+	if !assert.NotNil(t, link) {
+		return
+	}
+
+	// 未验证时不能发布文章
+	rec = client.do("GET", "/articles/create", "")
+	assert.Equal(t, "/auth/verify-email", rec.Header().Get("Location"))
+	rec = client.do("POST", "/api/v1/articles", `{"title":"Unverified","body":"Unverified body"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 重新发送
+	client.do("POST", "/auth/verify-email/resend", "")
+	assert.Len(t, mailer.Messages(), 2)
+
+	// 篡改签名或过期的链接无效
+	assert.Equal(t, http.StatusForbidden, client.do("GET", strings.Replace(link[1], "signature=", "signature=0", 1), "").Code)
+	assert.Equal(t, http.StatusForbidden, client.do("GET", strings.Replace(link[1], "expires=", "expires=1", 1), "").Code)
+
+	// 验证链接无需登录
+	guest := &apiClient{router: router}
+	rec = guest.do("GET", link[1], "")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	_user, _ := user.GetByEmail("summer@example.com")
+	assert.True(t, _user.IsVerified())
+
+	assert.Equal(t, http.StatusOK, client.do("GET", "/articles/create", "").Code)
+}
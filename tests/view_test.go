@@ -0,0 +1,24 @@
+package tests
+
+import (
+	"goblog/pkg/view"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chdirToRoot 模板使用相对于项目根目录的路径，测试时需切换工作目录
+func chdirToRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(".."))
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+}
+
+func TestCompileAllTemplates(t *testing.T) {
+	chdirToRoot(t)
+	assert.NoError(t, view.Compile())
+}